@@ -0,0 +1,137 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/kyma-project/cli.v3/internal/kube"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// createdByLabelSelector selects resources created by the app push command
+	createdByLabelSelector = "app.kubernetes.io/created-by=kyma-cli"
+	nameLabel              = "app.kubernetes.io/name"
+)
+
+var GVRAPIRule = schema.GroupVersionResource{
+	Group:    "gateway.kyma-project.io",
+	Version:  "v2alpha1",
+	Resource: "apirules",
+}
+
+// App describes the application pushed to the cluster
+type App struct {
+	Name          string `json:"name" yaml:"name"`
+	Namespace     string `json:"namespace" yaml:"namespace"`
+	Image         string `json:"image" yaml:"image"`
+	Replicas      int32  `json:"replicas" yaml:"replicas"`
+	ReadyReplicas int32  `json:"readyReplicas" yaml:"readyReplicas"`
+	Port          int32  `json:"port,omitempty" yaml:"port,omitempty"`
+	URL           string `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+// List returns apps pushed to the namespace with ports of their services and URLs of their APIRules
+func List(ctx context.Context, client kube.Client, namespace string) ([]App, error) {
+	listOpts := metav1.ListOptions{LabelSelector: createdByLabelSelector}
+	deployments, err := client.Static().AppsV1().Deployments(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := client.Static().CoreV1().Services(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	apiRules, err := listAPIRules(ctx, client, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	apps := []App{}
+	for _, deployment := range deployments.Items {
+		app := App{
+			Name:          deployment.GetName(),
+			Namespace:     deployment.GetNamespace(),
+			ReadyReplicas: deployment.Status.ReadyReplicas,
+		}
+		if deployment.Spec.Replicas != nil {
+			app.Replicas = *deployment.Spec.Replicas
+		}
+		if containers := deployment.Spec.Template.Spec.Containers; len(containers) > 0 {
+			app.Image = containers[0].Image
+		}
+
+		for _, service := range services.Items {
+			if service.GetName() == app.Name && len(service.Spec.Ports) > 0 {
+				app.Port = service.Spec.Ports[0].Port
+			}
+		}
+
+		for _, apiRule := range apiRules {
+			if apiRule.GetName() == app.Name {
+				app.URL = getAPIRuleURL(apiRule)
+			}
+		}
+
+		apps = append(apps, app)
+	}
+
+	return apps, nil
+}
+
+// RenderList renders apps in the given format
+func RenderList(writer io.Writer, apps []App, format types.Format) error {
+	return types.RenderFormat(writer, apps, format, func(writer io.Writer) {
+		rows := [][]string{}
+		for _, app := range apps {
+			rows = append(rows, []string{
+				app.Name,
+				app.Image,
+				fmt.Sprintf("%d/%d", app.ReadyReplicas, app.Replicas),
+				portOrDash(app.Port),
+				types.ValueOrDash(app.URL),
+			})
+		}
+
+		types.RenderTable(writer, rows, []string{"NAME", "IMAGE", "READY", "PORT", "URL"})
+	})
+}
+
+// listAPIRules returns APIRules created for apps
+// the list is empty if APIRules are not available on the cluster
+func listAPIRules(ctx context.Context, client kube.Client, namespace string) ([]unstructured.Unstructured, error) {
+	apiRules, err := client.Dynamic().Resource(GVRAPIRule).Namespace(namespace).
+		List(ctx, metav1.ListOptions{LabelSelector: createdByLabelSelector})
+	if apierrors.IsNotFound(err) {
+		return []unstructured.Unstructured{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return apiRules.Items, nil
+}
+
+func getAPIRuleURL(apiRule unstructured.Unstructured) string {
+	hosts, _, _ := unstructured.NestedStringSlice(apiRule.Object, "spec", "hosts")
+	if len(hosts) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("https://%s", hosts[0])
+}
+
+func portOrDash(port int32) string {
+	if port == 0 {
+		return "-"
+	}
+	return strconv.FormatInt(int64(port), 10)
+}
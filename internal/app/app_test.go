@@ -0,0 +1,169 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	kube_fake "github.com/kyma-project/cli.v3/internal/kube/fake"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamic_fake "k8s.io/client-go/dynamic/fake"
+	k8s_fake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+var testApps = []App{
+	{
+		Name:          "app",
+		Namespace:     "default",
+		Image:         "app:1.0.0",
+		Replicas:      2,
+		ReadyReplicas: 1,
+		Port:          8080,
+		URL:           "https://app.example.com",
+	},
+	{
+		Name:      "worker",
+		Namespace: "default",
+		Image:     "worker:1.0.0",
+		Replicas:  1,
+	},
+}
+
+func TestList(t *testing.T) {
+	t.Run("list apps", func(t *testing.T) {
+		client := &kube_fake.FakeKubeClient{
+			TestKubernetesInterface: k8s_fake.NewSimpleClientset(
+				fixDeployment("app", "app:1.0.0", 2, 1),
+				fixDeployment("worker", "worker:1.0.0", 1, 0),
+				fixService("app", 8080),
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "not-an-app", Namespace: "default"},
+				},
+			),
+			TestDynamicInterface: fixDynamicClient(fixAPIRule("app", "app.example.com")),
+		}
+
+		apps, err := List(context.Background(), client, "default")
+		require.NoError(t, err)
+		require.Equal(t, testApps, apps)
+	})
+
+	t.Run("list apps without APIRules available", func(t *testing.T) {
+		client := &kube_fake.FakeKubeClient{
+			TestKubernetesInterface: k8s_fake.NewSimpleClientset(
+				fixDeployment("worker", "worker:1.0.0", 1, 0),
+			),
+			TestDynamicInterface: fixDynamicClient(),
+		}
+
+		apps, err := List(context.Background(), client, "default")
+		require.NoError(t, err)
+		require.Equal(t, testApps[1:], apps)
+	})
+}
+
+func TestRenderList(t *testing.T) {
+	t.Run("render table", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+
+		err := RenderList(buffer, testApps, types.DefaultFormat)
+		require.NoError(t, err)
+		require.Contains(t, buffer.String(), "NAME")
+		require.Contains(t, buffer.String(), "https://app.example.com")
+		require.Contains(t, buffer.String(), "1/2")
+		require.Contains(t, buffer.String(), "8080")
+	})
+
+	t.Run("render yaml", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+
+		err := RenderList(buffer, testApps[1:], types.YAMLFormat)
+		require.NoError(t, err)
+		require.Equal(t, `- name: worker
+  namespace: default
+  image: worker:1.0.0
+  replicas: 1
+  readyReplicas: 0
+`, buffer.String())
+	})
+}
+
+func fixDeployment(name, image string, replicas, readyReplicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    fixAppLabels(name),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(replicas),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": name},
+			},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: name, Image: image},
+					},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas: readyReplicas,
+		},
+	}
+}
+
+func fixService(name string, port int32) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    fixAppLabels(name),
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Port: port}},
+		},
+	}
+}
+
+func fixAPIRule(name, host string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "gateway.kyma-project.io/v2alpha1",
+			"kind":       "APIRule",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "default",
+				"labels": map[string]interface{}{
+					"app.kubernetes.io/name":       name,
+					"app.kubernetes.io/created-by": "kyma-cli",
+				},
+			},
+			"spec": map[string]interface{}{
+				"hosts": []interface{}{host},
+			},
+		},
+	}
+}
+
+func fixAppLabels(name string) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       name,
+		"app.kubernetes.io/created-by": "kyma-cli",
+	}
+}
+
+func fixDynamicClient(objs ...runtime.Object) *dynamic_fake.FakeDynamicClient {
+	return dynamic_fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		GVRAPIRule: "APIRuleList",
+	}, objs...)
+}
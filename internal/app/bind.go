@@ -0,0 +1,210 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/kyma-project/cli.v3/internal/kube"
+	"github.com/kyma-project/cli.v3/internal/kube/btp"
+	"github.com/kyma-project/cli.v3/internal/kube/resources"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// BindingsRoot is the directory where binding secrets are mounted
+	// it follows the servicebinding.io convention and is exposed to the app as SERVICE_BINDING_ROOT
+	BindingsRoot = "/bindings"
+	// bindingMetadataKey is the key of the binding secret describing its credentials and metadata properties
+	bindingMetadataKey = ".metadata"
+)
+
+// Binding describes the BTP service instance bound to the app
+type Binding struct {
+	Instance string
+	Name     string
+}
+
+// BindingMounts returns mounts of binding secrets under the bindings root
+func BindingMounts(bindings []Binding) []resources.Mount {
+	mounts := []resources.Mount{}
+	for _, binding := range bindings {
+		mounts = append(mounts, resources.Mount{
+			Name: binding.Name,
+			Path: path.Join(BindingsRoot, binding.Name),
+		})
+	}
+
+	return mounts
+}
+
+// BuildServiceBinding returns the ServiceBinding of the instance owned by the app deployment
+// the binding secret has the same name as the binding
+func BuildServiceBinding(owner *appsv1.Deployment, binding Binding) *btp.ServiceBinding {
+	serviceBinding := &btp.ServiceBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: btp.ServicesAPIVersionV1,
+			Kind:       btp.KindServiceBinding,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      binding.Name,
+			Namespace: owner.GetNamespace(),
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       owner.GetName(),
+				"app.kubernetes.io/created-by": "kyma-cli",
+			},
+		},
+		Spec: btp.ServiceBindingSpec{
+			ServiceInstanceName: binding.Instance,
+			SecretName:          binding.Name,
+		},
+	}
+	if owner.GetUID() != "" {
+		serviceBinding.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(owner, appsv1.SchemeGroupVersion.WithKind("Deployment")),
+		}
+	}
+
+	return serviceBinding
+}
+
+// CreateServiceBindings creates missing ServiceBindings owned by the app deployment and waits until all of them are ready
+// existing bindings are reused so pushing the app again doesn't create new credentials
+func CreateServiceBindings(ctx context.Context, client kube.Client, writer io.Writer, owner *appsv1.Deployment, bindings []Binding, interval time.Duration) error {
+	for _, binding := range bindings {
+		_, err := client.Btp().GetServiceBinding(ctx, owner.GetNamespace(), binding.Name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get service binding %s: %w", binding.Name, err)
+		}
+		if apierrors.IsNotFound(err) {
+			fmt.Fprintf(writer, "Creating service binding %s/%s for the %s instance\n", owner.GetNamespace(), binding.Name, binding.Instance)
+			err = client.Btp().CreateServiceBinding(ctx, BuildServiceBinding(owner, binding))
+			if err != nil {
+				return fmt.Errorf("failed to create service binding %s: %w", binding.Name, err)
+			}
+		}
+	}
+
+	for _, binding := range bindings {
+		fmt.Fprintf(writer, "Waiting for service binding %s/%s to be ready\n", owner.GetNamespace(), binding.Name)
+		err := wait.PollUntilContextCancel(ctx, interval, true, client.Btp().IsBindingReady(ctx, owner.GetNamespace(), binding.Name))
+		if err != nil {
+			return fmt.Errorf("failed to wait for service binding %s: %w", binding.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// bindingMetadata describes properties stored in the binding secret by the BTP operator
+type bindingMetadata struct {
+	MetadataProperties   []bindingProperty `json:"metadataProperties"`
+	CredentialProperties []bindingProperty `json:"credentialProperties"`
+}
+
+type bindingProperty struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+}
+
+// BuildVCAPServices returns credentials of ready bindings in the VCAP_SERVICES format used by Cloud Foundry
+// services are grouped by the offering name, all secret keys are used as credentials if the secret has no metadata
+func BuildVCAPServices(ctx context.Context, client kube.Client, namespace string, bindings []Binding) (string, error) {
+	vcapServices := map[string][]map[string]interface{}{}
+	for _, binding := range bindings {
+		instance, err := client.Btp().GetServiceInstance(ctx, namespace, binding.Instance)
+		if err != nil {
+			return "", fmt.Errorf("failed to get service instance %s: %w", binding.Instance, err)
+		}
+
+		secret, err := client.Static().CoreV1().Secrets(namespace).Get(ctx, binding.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get secret of service binding %s: %w", binding.Name, err)
+		}
+
+		service, err := buildVCAPService(instance, binding, secret.Data)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret of service binding %s: %w", binding.Name, err)
+		}
+
+		label := service["label"].(string)
+		vcapServices[label] = append(vcapServices[label], service)
+	}
+
+	data, err := json.Marshal(vcapServices)
+	return string(data), err
+}
+
+func buildVCAPService(instance *btp.ServiceInstance, binding Binding, data map[string][]byte) (map[string]interface{}, error) {
+	service := map[string]interface{}{
+		"name":          instance.GetName(),
+		"instance_name": instance.GetName(),
+		"binding_name":  binding.Name,
+		"label":         instance.Spec.ServiceOfferingName,
+		"plan":          instance.Spec.ServicePlanName,
+		"tags":          []interface{}{},
+	}
+	credentials := map[string]interface{}{}
+
+	metadataData, ok := data[bindingMetadataKey]
+	if !ok {
+		for key, value := range data {
+			credentials[key] = string(value)
+		}
+		service["credentials"] = credentials
+		return service, nil
+	}
+
+	metadata := bindingMetadata{}
+	err := json.Unmarshal(metadataData, &metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, property := range metadata.MetadataProperties {
+		value, err := readBindingProperty(data, property)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			service[property.Name] = value
+		}
+	}
+	for _, property := range metadata.CredentialProperties {
+		value, err := readBindingProperty(data, property)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			credentials[property.Name] = value
+		}
+	}
+	service["credentials"] = credentials
+
+	return service, nil
+}
+
+// readBindingProperty returns the property value, values in the json format are decoded
+func readBindingProperty(data map[string][]byte, property bindingProperty) (interface{}, error) {
+	value, ok := data[property.Name]
+	if !ok {
+		return nil, nil
+	}
+
+	if property.Format != "json" {
+		return string(value), nil
+	}
+
+	var jsonValue interface{}
+	err := json.Unmarshal(value, &jsonValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid json value of the %s property: %w", property.Name, err)
+	}
+	return jsonValue, nil
+}
@@ -0,0 +1,254 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/cli.v3/internal/kube/btp"
+	kube_fake "github.com/kyma-project/cli.v3/internal/kube/fake"
+	"github.com/kyma-project/cli.v3/internal/kube/resources"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	dynamic_fake "k8s.io/client-go/dynamic/fake"
+	k8s_fake "k8s.io/client-go/kubernetes/fake"
+	k8s_testing "k8s.io/client-go/testing"
+)
+
+func TestBindingMounts(t *testing.T) {
+	mounts := BindingMounts([]Binding{
+		{Instance: "xsuaa", Name: "app-xsuaa"},
+		{Instance: "hana", Name: "db"},
+	})
+	require.Equal(t, []resources.Mount{
+		{Name: "app-xsuaa", Path: "/bindings/app-xsuaa"},
+		{Name: "db", Path: "/bindings/db"},
+	}, mounts)
+}
+
+func TestBuildServiceBinding(t *testing.T) {
+	t.Run("owner not stored in the cluster", func(t *testing.T) {
+		binding := BuildServiceBinding(fixDeployment("app", "nginx", 1, 0), Binding{Instance: "xsuaa", Name: "app-xsuaa"})
+		require.Equal(t, btp.KindServiceBinding, binding.Kind)
+		require.Equal(t, "app-xsuaa", binding.GetName())
+		require.Equal(t, fixAppLabels("app"), binding.GetLabels())
+		require.Equal(t, btp.ServiceBindingSpec{ServiceInstanceName: "xsuaa", SecretName: "app-xsuaa"}, binding.Spec)
+		require.Empty(t, binding.OwnerReferences)
+	})
+}
+
+func TestCreateServiceBindings(t *testing.T) {
+	t.Run("create missing binding", func(t *testing.T) {
+		dynamicClient := fixBindDynamicClient()
+		dynamicClient.PrependReactor("get", "servicebindings", func(action k8s_testing.Action) (bool, runtime.Object, error) {
+			// the btp operator is simulated by returning the created binding in the ready state
+			getAction := action.(k8s_testing.GetAction)
+			obj, err := dynamicClient.Tracker().Get(btp.GVRServiceBinding, getAction.GetNamespace(), getAction.GetName())
+			if err != nil {
+				return false, nil, nil
+			}
+			binding := obj.(*unstructured.Unstructured).DeepCopy()
+			status := fixReadyBindingStatus()
+			binding.Object["status"], _ = runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+			return true, binding, nil
+		})
+		client := &kube_fake.FakeKubeClient{
+			TestBtpInterface: btp.NewClient(dynamicClient),
+		}
+		owner := fixDeployment("app", "nginx", 1, 0)
+		owner.SetUID(types.UID("app-uid"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		writer := &bytes.Buffer{}
+		err := CreateServiceBindings(ctx, client, writer, owner, []Binding{{Instance: "xsuaa", Name: "app-xsuaa"}}, time.Millisecond)
+		require.NoError(t, err)
+		require.Contains(t, writer.String(), "Creating service binding default/app-xsuaa for the xsuaa instance")
+
+		binding, err := client.Btp().GetServiceBinding(context.Background(), "default", "app-xsuaa")
+		require.NoError(t, err)
+		require.Equal(t, "xsuaa", binding.Spec.ServiceInstanceName)
+		require.Equal(t, "app-xsuaa", binding.Spec.SecretName)
+		require.Equal(t, fixAppLabels("app"), binding.GetLabels())
+		require.Len(t, binding.OwnerReferences, 1)
+		require.Equal(t, types.UID("app-uid"), binding.OwnerReferences[0].UID)
+	})
+
+	t.Run("reuse existing binding", func(t *testing.T) {
+		dynamicClient := fixBindDynamicClient(fixServiceBinding("app-xsuaa", "xsuaa", fixReadyBindingStatus()))
+		client := &kube_fake.FakeKubeClient{
+			TestBtpInterface: btp.NewClient(dynamicClient),
+		}
+
+		writer := &bytes.Buffer{}
+		err := CreateServiceBindings(context.Background(), client, writer, fixDeployment("app", "nginx", 1, 0), []Binding{{Instance: "xsuaa", Name: "app-xsuaa"}}, time.Millisecond)
+		require.NoError(t, err)
+		require.NotContains(t, writer.String(), "Creating service binding")
+		for _, action := range dynamicClient.Actions() {
+			require.NotEqual(t, "create", action.GetVerb())
+		}
+	})
+
+	t.Run("binding failed", func(t *testing.T) {
+		status := btp.CommonStatus{
+			Ready: "False",
+			Conditions: []metav1.Condition{
+				{Type: "Failed", Status: metav1.ConditionTrue, Message: "instance not found"},
+			},
+		}
+		client := &kube_fake.FakeKubeClient{
+			TestBtpInterface: btp.NewClient(fixBindDynamicClient(fixServiceBinding("app-xsuaa", "xsuaa", status))),
+		}
+
+		err := CreateServiceBindings(context.Background(), client, &bytes.Buffer{}, fixDeployment("app", "nginx", 1, 0), []Binding{{Instance: "xsuaa", Name: "app-xsuaa"}}, time.Millisecond)
+		require.ErrorContains(t, err, "failed to wait for service binding app-xsuaa: instance not found")
+	})
+}
+
+func TestBuildVCAPServices(t *testing.T) {
+	t.Run("build from binding metadata", func(t *testing.T) {
+		client := &kube_fake.FakeKubeClient{
+			TestBtpInterface: btp.NewClient(fixBindDynamicClient(fixServiceInstance("xsuaa", "xsuaa", "application"))),
+			TestKubernetesInterface: k8s_fake.NewSimpleClientset(fixBindingSecret("app-xsuaa", map[string]string{
+				".metadata":     `{"metadataProperties":[{"name":"instance_guid","format":"text"}],"credentialProperties":[{"name":"clientid","format":"text"},{"name":"uaa","format":"json"}]}`,
+				"clientid":      "client",
+				"uaa":           `{"url":"https://uaa.example.com"}`,
+				"instance_guid": "guid",
+			})),
+		}
+
+		vcapServices, err := BuildVCAPServices(context.Background(), client, "default", []Binding{{Instance: "xsuaa", Name: "app-xsuaa"}})
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"xsuaa": [{
+				"name": "xsuaa",
+				"instance_name": "xsuaa",
+				"binding_name": "app-xsuaa",
+				"label": "xsuaa",
+				"plan": "application",
+				"tags": [],
+				"instance_guid": "guid",
+				"credentials": {
+					"clientid": "client",
+					"uaa": {"url": "https://uaa.example.com"}
+				}
+			}]
+		}`, vcapServices)
+	})
+
+	t.Run("build from all secret keys", func(t *testing.T) {
+		client := &kube_fake.FakeKubeClient{
+			TestBtpInterface: btp.NewClient(fixBindDynamicClient(fixServiceInstance("db", "postgresql-db", "trial"))),
+			TestKubernetesInterface: k8s_fake.NewSimpleClientset(fixBindingSecret("db", map[string]string{
+				"username": "user",
+			})),
+		}
+
+		vcapServices, err := BuildVCAPServices(context.Background(), client, "default", []Binding{{Instance: "db", Name: "db"}})
+		require.NoError(t, err)
+
+		services := map[string][]map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(vcapServices), &services))
+		require.Len(t, services["postgresql-db"], 1)
+		require.Equal(t, map[string]interface{}{"username": "user"}, services["postgresql-db"][0]["credentials"])
+	})
+
+	t.Run("invalid json property", func(t *testing.T) {
+		client := &kube_fake.FakeKubeClient{
+			TestBtpInterface: btp.NewClient(fixBindDynamicClient(fixServiceInstance("xsuaa", "xsuaa", "application"))),
+			TestKubernetesInterface: k8s_fake.NewSimpleClientset(fixBindingSecret("app-xsuaa", map[string]string{
+				".metadata": `{"credentialProperties":[{"name":"uaa","format":"json"}]}`,
+				"uaa":       "{",
+			})),
+		}
+
+		_, err := BuildVCAPServices(context.Background(), client, "default", []Binding{{Instance: "xsuaa", Name: "app-xsuaa"}})
+		require.ErrorContains(t, err, "invalid json value of the uaa property")
+	})
+
+	t.Run("missing binding secret", func(t *testing.T) {
+		client := &kube_fake.FakeKubeClient{
+			TestBtpInterface:        btp.NewClient(fixBindDynamicClient(fixServiceInstance("xsuaa", "xsuaa", "application"))),
+			TestKubernetesInterface: k8s_fake.NewSimpleClientset(),
+		}
+
+		_, err := BuildVCAPServices(context.Background(), client, "default", []Binding{{Instance: "xsuaa", Name: "app-xsuaa"}})
+		require.ErrorContains(t, err, "failed to get secret of service binding app-xsuaa")
+	})
+}
+
+func fixBindDynamicClient(objs ...runtime.Object) *dynamic_fake.FakeDynamicClient {
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypes(btp.GVRServiceBinding.GroupVersion())
+	return dynamic_fake.NewSimpleDynamicClient(scheme, objs...)
+}
+
+func fixReadyBindingStatus() btp.CommonStatus {
+	return btp.CommonStatus{
+		Ready: "True",
+		Conditions: []metav1.Condition{
+			{Type: "Succeeded", Status: metav1.ConditionTrue},
+			{Type: "Ready", Status: metav1.ConditionTrue},
+		},
+	}
+}
+
+func fixServiceBinding(name, instance string, status btp.CommonStatus) *unstructured.Unstructured {
+	binding := &btp.ServiceBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: btp.ServicesAPIVersionV1,
+			Kind:       btp.KindServiceBinding,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: btp.ServiceBindingSpec{
+			ServiceInstanceName: instance,
+			SecretName:          name,
+		},
+		Status: status,
+	}
+	obj, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(binding)
+	return &unstructured.Unstructured{Object: obj}
+}
+
+func fixServiceInstance(name, offering, plan string) *unstructured.Unstructured {
+	instance := &btp.ServiceInstance{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: btp.ServicesAPIVersionV1,
+			Kind:       btp.KindServiceInstance,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: btp.ServiceInstanceSpec{
+			ServiceOfferingName: offering,
+			ServicePlanName:     plan,
+		},
+	}
+	obj, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(instance)
+	return &unstructured.Unstructured{Object: obj}
+}
+
+func fixBindingSecret(name string, data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Data: map[string][]byte{},
+	}
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+	return secret
+}
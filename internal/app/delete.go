@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyma-project/cli.v3/internal/kube"
+	"github.com/kyma-project/cli.v3/internal/kube/resources"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+type appResource struct {
+	gvr  schema.GroupVersionResource
+	kind string
+	name string
+}
+
+// Delete removes the app deployment, service, APIRule and ConfigMap and Secrets generated for its variables
+// service bindings created for the app are removed by the garbage collector together with the deployment
+// it returns removed resources in the format 'kind/name'
+// resources that don't exist or weren't created by the app push command are skipped
+func Delete(ctx context.Context, client kube.Client, name, namespace string) ([]string, error) {
+	appResources := []appResource{
+		{gvr: GVRAPIRule, kind: "apirule", name: name},
+		{gvr: schema.GroupVersionResource{Version: "v1", Resource: "services"}, kind: "service", name: name},
+		{gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, kind: "deployment", name: name},
+		{gvr: schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, kind: "configmap", name: resources.EnvObjectName(name)},
+		{gvr: schema.GroupVersionResource{Version: "v1", Resource: "secrets"}, kind: "secret", name: resources.EnvObjectName(name)},
+		{gvr: schema.GroupVersionResource{Version: "v1", Resource: "secrets"}, kind: "secret", name: resources.VCAPObjectName(name)},
+	}
+
+	deleted := []string{}
+	for _, resource := range appResources {
+		removed, err := deleteAppResource(ctx, client, resource, name, namespace)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete %s/%s: %w", resource.kind, resource.name, err)
+		}
+
+		if removed {
+			deleted = append(deleted, fmt.Sprintf("%s/%s", resource.kind, resource.name))
+		}
+	}
+
+	return deleted, nil
+}
+
+func deleteAppResource(ctx context.Context, client kube.Client, resource appResource, appName, namespace string) (bool, error) {
+	resourceClient := client.Dynamic().Resource(resource.gvr).Namespace(namespace)
+	obj, err := resourceClient.Get(ctx, resource.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	labels := obj.GetLabels()
+	if labels["app.kubernetes.io/created-by"] != "kyma-cli" || labels[nameLabel] != appName {
+		return false, nil
+	}
+
+	err = resourceClient.Delete(ctx, resource.name, metav1.DeleteOptions{
+		PropagationPolicy: ptr.To(metav1.DeletePropagationForeground),
+	})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	kube_fake "github.com/kyma-project/cli.v3/internal/kube/fake"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamic_fake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestDelete(t *testing.T) {
+	t.Run("delete app resources", func(t *testing.T) {
+		dynamicClient := dynamic_fake.NewSimpleDynamicClient(runtime.NewScheme(),
+			fixAPIRule("app", "app.example.com"),
+			fixUnstructured("v1", "Service", "app", fixAppLabels("app")),
+			fixUnstructured("apps/v1", "Deployment", "app", fixAppLabels("app")),
+			fixUnstructured("v1", "ConfigMap", "app-env", fixAppLabels("app")),
+			fixUnstructured("v1", "Secret", "app-env", map[string]string{"owner": "user"}),
+			fixUnstructured("v1", "Secret", "app-vcap", fixAppLabels("app")),
+		)
+		client := &kube_fake.FakeKubeClient{
+			TestDynamicInterface: dynamicClient,
+		}
+
+		deleted, err := Delete(context.Background(), client, "app", "default")
+		require.NoError(t, err)
+		require.Equal(t, []string{"apirule/app", "service/app", "deployment/app", "configmap/app-env", "secret/app-vcap"}, deleted)
+
+		// secret not created by the cli is not removed
+		_, err = dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "secrets"}).
+			Namespace("default").Get(context.Background(), "app-env", metav1.GetOptions{})
+		require.NoError(t, err)
+	})
+
+	t.Run("nothing to delete", func(t *testing.T) {
+		client := &kube_fake.FakeKubeClient{
+			TestDynamicInterface: dynamic_fake.NewSimpleDynamicClient(runtime.NewScheme()),
+		}
+
+		deleted, err := Delete(context.Background(), client, "app", "default")
+		require.NoError(t, err)
+		require.Empty(t, deleted)
+	})
+}
+
+func fixUnstructured(apiVersion, kind, name string, labels map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetNamespace("default")
+	obj.SetLabels(labels)
+	return obj
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultDescriptorFile is the conventional name of the app descriptor kept in the app repository
+const DefaultDescriptorFile = "kyma-app.yaml"
+
+// Descriptor describes the app pushed with the 'app push' command
+// it contains the same settings as flags of the command, empty values are not set
+type Descriptor struct {
+	Name              string              `yaml:"name"`
+	Namespace         string              `yaml:"namespace"`
+	Image             string              `yaml:"image"`
+	Dockerfile        string              `yaml:"dockerfile"`
+	DockerfileContext string              `yaml:"dockerfileContext"`
+	ContainerPort     *int64              `yaml:"containerPort"`
+	IstioInject       *bool               `yaml:"istioInject"`
+	Replicas          *int32              `yaml:"replicas"`
+	Command           []string            `yaml:"command"`
+	Args              []string            `yaml:"args"`
+	Resources         DescriptorResources `yaml:"resources"`
+	LivenessProbe     string              `yaml:"livenessProbe"`
+	ReadinessProbe    string              `yaml:"readinessProbe"`
+	Env               DescriptorEnv       `yaml:"env"`
+	Expose            DescriptorExpose    `yaml:"expose"`
+	Bind              DescriptorBind      `yaml:"bind"`
+}
+
+type DescriptorResources struct {
+	CPURequest    string `yaml:"cpuRequest"`
+	CPULimit      string `yaml:"cpuLimit"`
+	MemoryRequest string `yaml:"memoryRequest"`
+	MemoryLimit   string `yaml:"memoryLimit"`
+}
+
+// DescriptorEnv describes variables of the app and mounted ConfigMaps and Secrets
+// mounts are in the format 'name:/path'
+type DescriptorEnv struct {
+	Values          map[string]string `yaml:"values"`
+	File            string            `yaml:"file"`
+	SecretFile      string            `yaml:"secretFile"`
+	FromConfigMaps  []string          `yaml:"fromConfigMaps"`
+	FromSecrets     []string          `yaml:"fromSecrets"`
+	MountConfigMaps []string          `yaml:"mountConfigMaps"`
+	MountSecrets    []string          `yaml:"mountSecrets"`
+}
+
+type DescriptorExpose struct {
+	Enabled        bool          `yaml:"enabled"`
+	Domain         string        `yaml:"domain"`
+	Jwt            DescriptorJwt `yaml:"jwt"`
+	ExtAuthorizers []string      `yaml:"extAuthorizers"`
+	RulesFile      string        `yaml:"rulesFile"`
+}
+
+type DescriptorJwt struct {
+	Issuer         string   `yaml:"issuer"`
+	JwksURI        string   `yaml:"jwksUri"`
+	RequiredScopes []string `yaml:"requiredScopes"`
+}
+
+// DescriptorBind describes BTP service instances bound to the app
+// services are in the format '<serviceInstance>[:bindingName]'
+type DescriptorBind struct {
+	Services []string `yaml:"services"`
+	Format   string   `yaml:"format"`
+}
+
+// ReadDescriptor reads the app descriptor from the yaml file
+// unknown fields are not allowed, relative paths are resolved against the directory of the file
+func ReadDescriptor(path string) (*Descriptor, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptor := &Descriptor{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err = decoder.Decode(descriptor)
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Dir(path)
+	for _, filePath := range []*string{
+		&descriptor.Dockerfile,
+		&descriptor.DockerfileContext,
+		&descriptor.Env.File,
+		&descriptor.Env.SecretFile,
+		&descriptor.Expose.RulesFile,
+	} {
+		*filePath = resolvePath(dir, *filePath)
+	}
+
+	return descriptor, nil
+}
+
+func resolvePath(dir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(dir, path)
+}
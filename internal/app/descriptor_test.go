@@ -0,0 +1,104 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestReadDescriptor(t *testing.T) {
+	t.Run("read descriptor", func(t *testing.T) {
+		dir := t.TempDir()
+		path := fixDescriptorFile(t, dir, `name: app
+namespace: dev
+dockerfile: ./Dockerfile
+dockerfileContext: .
+containerPort: 8080
+istioInject: true
+replicas: 2
+resources:
+  cpuLimit: 200m
+env:
+  values:
+    KEY: VALUE
+  file: app.env
+  secretFile: /etc/app/secret.env
+  mountConfigMaps:
+  - config:/etc/config
+expose:
+  enabled: true
+  jwt:
+    issuer: https://issuer.example.com
+    jwksUri: https://issuer.example.com/keys
+    requiredScopes: [read]
+  rulesFile: rules.yaml
+bind:
+  services:
+  - xsuaa
+  - hana:db
+  format: vcap
+`)
+
+		descriptor, err := ReadDescriptor(path)
+		require.NoError(t, err)
+		require.Equal(t, &Descriptor{
+			Name:              "app",
+			Namespace:         "dev",
+			Dockerfile:        filepath.Join(dir, "Dockerfile"),
+			DockerfileContext: dir,
+			ContainerPort:     ptr.To(int64(8080)),
+			IstioInject:       ptr.To(true),
+			Replicas:          ptr.To(int32(2)),
+			Resources: DescriptorResources{
+				CPULimit: "200m",
+			},
+			Env: DescriptorEnv{
+				Values:          map[string]string{"KEY": "VALUE"},
+				File:            filepath.Join(dir, "app.env"),
+				SecretFile:      "/etc/app/secret.env",
+				MountConfigMaps: []string{"config:/etc/config"},
+			},
+			Expose: DescriptorExpose{
+				Enabled: true,
+				Jwt: DescriptorJwt{
+					Issuer:         "https://issuer.example.com",
+					JwksURI:        "https://issuer.example.com/keys",
+					RequiredScopes: []string{"read"},
+				},
+				RulesFile: filepath.Join(dir, "rules.yaml"),
+			},
+			Bind: DescriptorBind{
+				Services: []string{"xsuaa", "hana:db"},
+				Format:   "vcap",
+			},
+		}, descriptor)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		path := fixDescriptorFile(t, t.TempDir(), "name: app\nport: 8080\n")
+
+		_, err := ReadDescriptor(path)
+		require.ErrorContains(t, err, "field port not found")
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		path := fixDescriptorFile(t, t.TempDir(), "")
+
+		_, err := ReadDescriptor(path)
+		require.EqualError(t, err, "file is empty")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := ReadDescriptor(filepath.Join(t.TempDir(), DefaultDescriptorFile))
+		require.Error(t, err)
+	})
+}
+
+func fixDescriptorFile(t *testing.T, dir, content string) string {
+	path := filepath.Join(dir, DefaultDescriptorFile)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
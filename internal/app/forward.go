@@ -0,0 +1,283 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/kyma-project/cli.v3/internal/kube"
+	"github.com/kyma-project/cli.v3/internal/registry/portforward"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ErrNoReadyPods is returned when there is no ready pod to forward connections to
+var ErrNoReadyPods = errors.New("no ready pods found")
+
+// ForwardOptions configures ports of the forwarding
+// the remote port is taken from the service or the app container if empty, the local port is the same as the remote one if empty
+type ForwardOptions struct {
+	LocalPort  int32
+	RemotePort int32
+}
+
+// forwardTarget describes pods selected for forwarding and their port
+// the port name is set when the service target port refers to a named container port
+type forwardTarget struct {
+	selector string
+	port     int32
+	portName string
+}
+
+// Forward listens on the local port and forwards every connection to a ready pod of the app or the service
+// the pod is selected again when the connection to the previous one is lost, for example after the pod restart
+// it blocks until the context is canceled
+func Forward(ctx context.Context, client kube.Client, writer io.Writer, name, namespace string, opts ForwardOptions) error {
+	target, err := getForwardTarget(ctx, client, name, namespace, opts.RemotePort)
+	if err != nil {
+		return err
+	}
+
+	localPort := opts.LocalPort
+	if localPort == 0 {
+		localPort = target.port
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(int(localPort))))
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	fmt.Fprintf(writer, "Forwarding from %s to %s/%s\n", listener.Addr().String(), namespace, name)
+
+	f := &forwarder{
+		client:    client,
+		namespace: namespace,
+		target:    target,
+		writer:    writer,
+	}
+	defer f.close()
+
+	for {
+		localConn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		go f.handleConnection(ctx, localConn)
+	}
+}
+
+// getForwardTarget returns target based on the service with the given name
+// pods labeled with the app name are used if the service doesn't exist
+func getForwardTarget(ctx context.Context, client kube.Client, name, namespace string, remotePort int32) (forwardTarget, error) {
+	service, err := client.Static().CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return forwardTarget{}, err
+	}
+	if err == nil && len(service.Spec.Selector) > 0 {
+		return getServiceTarget(service, remotePort)
+	}
+
+	target := forwardTarget{
+		selector: labels.SelectorFromSet(map[string]string{"app": name}).String(),
+		port:     remotePort,
+	}
+	if target.port != 0 {
+		return target, nil
+	}
+
+	deployment, err := client.Static().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return forwardTarget{}, err
+	}
+
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if len(container.Ports) > 0 {
+			target.port = container.Ports[0].ContainerPort
+			return target, nil
+		}
+	}
+
+	return forwardTarget{}, fmt.Errorf("app %s/%s doesn't expose any port", namespace, name)
+}
+
+// getServiceTarget translates the service port to the target port of its pods
+// the remote port is used as is if the service doesn't define it
+func getServiceTarget(service *corev1.Service, remotePort int32) (forwardTarget, error) {
+	target := forwardTarget{
+		selector: labels.SelectorFromSet(service.Spec.Selector).String(),
+		port:     remotePort,
+	}
+
+	for _, servicePort := range service.Spec.Ports {
+		if remotePort != 0 && servicePort.Port != remotePort {
+			continue
+		}
+
+		target.port = servicePort.Port
+		switch {
+		case servicePort.TargetPort.Type == intstr.String:
+			target.portName = servicePort.TargetPort.StrVal
+		case servicePort.TargetPort.IntVal != 0:
+			target.port = servicePort.TargetPort.IntVal
+		}
+		return target, nil
+	}
+
+	if remotePort == 0 {
+		return forwardTarget{}, fmt.Errorf("service %s/%s doesn't expose any port", service.GetNamespace(), service.GetName())
+	}
+	return target, nil
+}
+
+// resolvePort returns the port of the pod, named ports are resolved based on container ports
+func (t forwardTarget) resolvePort(pod *corev1.Pod) (int32, error) {
+	if t.portName == "" {
+		return t.port, nil
+	}
+
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == t.portName {
+				return port.ContainerPort, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("pod %s doesn't have the %s port", pod.GetName(), t.portName)
+}
+
+// selectReadyPod returns the first running and ready pod matching the selector
+func selectReadyPod(ctx context.Context, client kube.Client, namespace, selector string) (*corev1.Pod, error) {
+	pods, err := client.Static().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp == nil && pod.Status.Phase == corev1.PodRunning && isPodReady(pod) {
+			return pod, nil
+		}
+	}
+
+	return nil, ErrNoReadyPods
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// forwarder shares the port-forward connection to the selected pod between local connections
+type forwarder struct {
+	client    kube.Client
+	namespace string
+	target    forwardTarget
+	writer    io.Writer
+
+	mu      sync.Mutex
+	podName string
+	port    int32
+	conn    httpstream.Connection
+}
+
+func (f *forwarder) handleConnection(ctx context.Context, localConn net.Conn) {
+	remoteConn, port, err := f.getConnection(ctx)
+	if err != nil {
+		fmt.Fprintf(f.writer, "failed to forward connection: %s\n", err.Error())
+		localConn.Close()
+		return
+	}
+
+	err = portforward.ForwardConnection(remoteConn, strconv.Itoa(int(port)), localConn)
+	if err != nil {
+		fmt.Fprintf(f.writer, "failed to forward connection: %s\n", err.Error())
+		// the pod may be restarted so the next connection selects it again
+		f.reset(remoteConn)
+	}
+}
+
+// getConnection returns the current connection or opens the new one to the selected ready pod
+func (f *forwarder) getConnection(ctx context.Context) (httpstream.Connection, int32, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.conn != nil {
+		select {
+		case <-f.conn.CloseChan():
+			f.conn = nil
+		default:
+			return f.conn, f.port, nil
+		}
+	}
+
+	pod, err := selectReadyPod(ctx, f.client, f.namespace, f.target.selector)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	port, err := f.target.resolvePort(pod)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	conn, err := portforward.NewDialFor(f.client.RestConfig(), pod.GetName(), pod.GetNamespace())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if pod.GetName() != f.podName {
+		fmt.Fprintf(f.writer, "Forwarding to the %s pod port %d\n", pod.GetName(), port)
+	}
+
+	f.podName = pod.GetName()
+	f.port = port
+	f.conn = conn
+	return conn, port, nil
+}
+
+func (f *forwarder) reset(conn httpstream.Connection) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.conn == conn {
+		f.conn.Close()
+		f.conn = nil
+	}
+}
+
+func (f *forwarder) close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.conn != nil {
+		f.conn.Close()
+		f.conn = nil
+	}
+}
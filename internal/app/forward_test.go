@@ -0,0 +1,159 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	kube_fake "github.com/kyma-project/cli.v3/internal/kube/fake"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	k8s_fake "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_getForwardTarget(t *testing.T) {
+	tests := []struct {
+		name          string
+		objs          []runtime.Object
+		remotePort    int32
+		want          forwardTarget
+		expectedError string
+	}{
+		{
+			name: "service target port",
+			objs: []runtime.Object{
+				fixForwardService(intstr.FromInt32(8080)),
+			},
+			want: forwardTarget{selector: "app=app", port: 8080},
+		},
+		{
+			name: "service named target port",
+			objs: []runtime.Object{
+				fixForwardService(intstr.FromString("http")),
+			},
+			remotePort: 80,
+			want:       forwardTarget{selector: "app=app", port: 80, portName: "http"},
+		},
+		{
+			name: "port not defined in service",
+			objs: []runtime.Object{
+				fixForwardService(intstr.FromInt32(8080)),
+			},
+			remotePort: 9090,
+			want:       forwardTarget{selector: "app=app", port: 9090},
+		},
+		{
+			name: "app container port",
+			objs: []runtime.Object{
+				fixForwardDeployment(5000),
+			},
+			want: forwardTarget{selector: "app=app", port: 5000},
+		},
+		{
+			name: "app remote port",
+			objs: []runtime.Object{
+				fixForwardDeployment(5000),
+			},
+			remotePort: 6000,
+			want:       forwardTarget{selector: "app=app", port: 6000},
+		},
+		{
+			name: "app without port",
+			objs: []runtime.Object{
+				fixDeployment("app", "app:1.0.0", 1, 1),
+			},
+			expectedError: "app default/app doesn't expose any port",
+		},
+		{
+			name:          "app not found",
+			expectedError: "deployments.apps \"app\" not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &kube_fake.FakeKubeClient{
+				TestKubernetesInterface: k8s_fake.NewSimpleClientset(tt.objs...),
+			}
+
+			got, err := getForwardTarget(context.Background(), client, "app", "default", tt.remotePort)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_forwardTarget_resolvePort(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-5d4f-abcde"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}}},
+			},
+		},
+	}
+
+	port, err := forwardTarget{port: 80, portName: "http"}.resolvePort(pod)
+	require.NoError(t, err)
+	require.Equal(t, int32(8080), port)
+
+	port, err = forwardTarget{port: 80}.resolvePort(pod)
+	require.NoError(t, err)
+	require.Equal(t, int32(80), port)
+
+	_, err = forwardTarget{port: 80, portName: "grpc"}.resolvePort(pod)
+	require.EqualError(t, err, "pod app-5d4f-abcde doesn't have the grpc port")
+}
+
+func Test_selectReadyPod(t *testing.T) {
+	t.Run("select ready pod", func(t *testing.T) {
+		client := &kube_fake.FakeKubeClient{
+			TestKubernetesInterface: k8s_fake.NewSimpleClientset(
+				fixForwardPod("app-5d4f-abcde", corev1.PodPending, corev1.ConditionFalse),
+				fixForwardPod("app-5d4f-fghij", corev1.PodRunning, corev1.ConditionFalse),
+				fixForwardPod("app-5d4f-klmno", corev1.PodRunning, corev1.ConditionTrue),
+			),
+		}
+
+		pod, err := selectReadyPod(context.Background(), client, "default", "app=app")
+		require.NoError(t, err)
+		require.Equal(t, "app-5d4f-klmno", pod.GetName())
+	})
+
+	t.Run("no ready pods", func(t *testing.T) {
+		client := &kube_fake.FakeKubeClient{
+			TestKubernetesInterface: k8s_fake.NewSimpleClientset(
+				fixForwardPod("app-5d4f-fghij", corev1.PodRunning, corev1.ConditionFalse),
+			),
+		}
+
+		_, err := selectReadyPod(context.Background(), client, "default", "app=app")
+		require.ErrorIs(t, err, ErrNoReadyPods)
+	})
+}
+
+func fixForwardService(targetPort intstr.IntOrString) *corev1.Service {
+	service := fixService("app", 80)
+	service.Spec.Selector = map[string]string{"app": "app"}
+	service.Spec.Ports[0].TargetPort = targetPort
+	return service
+}
+
+func fixForwardDeployment(port int32) runtime.Object {
+	deployment := fixDeployment("app", "app:1.0.0", 1, 1)
+	deployment.Spec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{{ContainerPort: port}}
+	return deployment
+}
+
+func fixForwardPod(name string, phase corev1.PodPhase, ready corev1.ConditionStatus) *corev1.Pod {
+	pod := fixPod(name, "app", phase, ready == corev1.ConditionTrue, 0, nil)
+	pod.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodReady, Status: ready},
+	}
+	return pod
+}
@@ -0,0 +1,246 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/kyma-project/cli.v3/internal/kube"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/utils/ptr"
+)
+
+// LogsOptions configures logs streamed from pods of the app
+type LogsOptions struct {
+	Follow    bool
+	Since     time.Duration
+	Container string
+	Previous  bool
+}
+
+var (
+	// ErrNoPods is returned when the app has no pods to stream logs from
+	ErrNoPods = errors.New("no pods found")
+	// ErrNoPreviousContainers is returned when no pod of the app has a restarted container to print previous logs from
+	ErrNoPreviousContainers = errors.New("no pods with a restarted container found")
+)
+
+// StreamLogs streams logs from all pods of the app concurrently
+// every line is prefixed with the pod name, the app container is used if the container is not set
+// a pod failing to stream its logs is reported to the warnWriter and doesn't stop other pods
+// new pods of the app are streamed as soon as their container starts if logs are followed
+func StreamLogs(ctx context.Context, client kube.Client, writer, warnWriter io.Writer, name, namespace string, opts LogsOptions) error {
+	selector := fmt.Sprintf("app=%s", name)
+	pods, err := client.Static().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return err
+	}
+	if len(pods.Items) == 0 {
+		return ErrNoPods
+	}
+
+	container := opts.Container
+	if container == "" {
+		// the app container is named after the app
+		container = name
+	}
+
+	streamer := &logsStreamer{
+		client:     client,
+		namespace:  namespace,
+		writer:     &lineWriter{writer: writer},
+		warnWriter: warnWriter,
+		opts:       buildPodLogOptions(container, opts),
+		streamed:   map[string]bool{},
+	}
+	for _, pod := range pods.Items {
+		if opts.Previous && !hasPreviousContainer(pod, container) {
+			continue
+		}
+		if opts.Follow && !isContainerStarted(pod, container) {
+			// the pod is streamed by the watch once its container starts
+			continue
+		}
+
+		streamer.stream(ctx, pod.GetName())
+	}
+	if opts.Previous && len(streamer.streamed) == 0 {
+		return ErrNoPreviousContainers
+	}
+
+	if opts.Follow {
+		err = streamer.watchNewPods(ctx, selector, container)
+	}
+
+	return errors.Join(err, streamer.wait())
+}
+
+func buildPodLogOptions(container string, opts LogsOptions) *corev1.PodLogOptions {
+	logOpts := &corev1.PodLogOptions{
+		Container: container,
+		Follow:    opts.Follow,
+		Previous:  opts.Previous,
+	}
+	if opts.Since > 0 {
+		// the API accepts only whole seconds so the duration is rounded up to not skip any logs
+		logOpts.SinceSeconds = ptr.To(int64(math.Ceil(opts.Since.Seconds())))
+	}
+
+	return logOpts
+}
+
+// hasPreviousContainer returns true if the pod container was restarted so logs of its previous instance are available
+func hasPreviousContainer(pod corev1.Pod, container string) bool {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Name == container {
+			return containerStatus.RestartCount > 0 || containerStatus.LastTerminationState.Terminated != nil
+		}
+	}
+
+	return false
+}
+
+// isContainerStarted returns true if the pod container is running or already terminated so its logs can be streamed
+func isContainerStarted(pod corev1.Pod, container string) bool {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Name == container {
+			return containerStatus.State.Running != nil || containerStatus.State.Terminated != nil
+		}
+	}
+
+	return false
+}
+
+// logsStreamer streams logs from many pods and collects their failures
+type logsStreamer struct {
+	client     kube.Client
+	namespace  string
+	writer     *lineWriter
+	warnWriter io.Writer
+	opts       *corev1.PodLogOptions
+
+	// streamed is accessed only by the goroutine starting streams
+	streamed map[string]bool
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	failed   int
+}
+
+// stream starts streaming logs of the pod in the background
+func (ls *logsStreamer) stream(ctx context.Context, podName string) {
+	ls.streamed[podName] = true
+	ls.wg.Add(1)
+	go func() {
+		defer ls.wg.Done()
+
+		err := streamPodLogs(ctx, ls.client, ls.writer, podName, ls.namespace, ls.opts)
+		if err != nil {
+			ls.mu.Lock()
+			defer ls.mu.Unlock()
+
+			ls.failed++
+			fmt.Fprintf(ls.warnWriter, "warning: failed to stream logs of the %s pod: %s\n", podName, err.Error())
+		}
+	}()
+}
+
+// watchNewPods streams logs of pods which container has started after the streaming began
+// it returns when the context is canceled
+func (ls *logsStreamer) watchNewPods(ctx context.Context, selector, container string) error {
+	for ctx.Err() == nil {
+		// the watch starts with the current state of pods so no pod created in the meantime is missed
+		watcher, err := ls.client.Static().CoreV1().Pods(ls.namespace).Watch(ctx, metav1.ListOptions{
+			LabelSelector: selector,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		ls.handlePodEvents(ctx, watcher, container)
+		// the watch is closed by the server after a timeout and is started again
+		watcher.Stop()
+	}
+
+	return nil
+}
+
+// handlePodEvents streams logs of started pods until the watch is closed or the context is canceled
+func (ls *logsStreamer) handlePodEvents(ctx context.Context, watcher watch.Interface, container string) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return
+			}
+
+			pod, isPod := event.Object.(*corev1.Pod)
+			if !isPod || (event.Type != watch.Added && event.Type != watch.Modified) {
+				continue
+			}
+
+			if !ls.streamed[pod.GetName()] && isContainerStarted(*pod, container) {
+				ls.stream(ctx, pod.GetName())
+			}
+		}
+	}
+}
+
+// wait waits for all streams and returns an error if none of them succeeded
+func (ls *logsStreamer) wait() error {
+	ls.wg.Wait()
+
+	if ls.failed > 0 && ls.failed == len(ls.streamed) {
+		return fmt.Errorf("failed to stream logs of all %d pods", ls.failed)
+	}
+	return nil
+}
+
+func streamPodLogs(ctx context.Context, client kube.Client, writer *lineWriter, podName, namespace string, opts *corev1.PodLogOptions) error {
+	stream, err := client.Static().CoreV1().Pods(namespace).GetLogs(podName, opts).Stream(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			// streaming is interrupted before it started
+			return nil
+		}
+		return err
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		writer.writeLine(podName, scanner.Text())
+	}
+
+	if ctx.Err() != nil {
+		// stream is closed by the canceled context
+		return nil
+	}
+	return scanner.Err()
+}
+
+// lineWriter writes lines from many pods without mixing them up
+type lineWriter struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+func (lw *lineWriter) writeLine(podName, line string) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	fmt.Fprintf(lw.writer, "[%s] %s\n", podName, line)
+}
@@ -0,0 +1,160 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	kube_fake "github.com/kyma-project/cli.v3/internal/kube/fake"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_fake "k8s.io/client-go/kubernetes/fake"
+	k8s_testing "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func TestStreamLogs(t *testing.T) {
+	t.Run("stream logs from all pods", func(t *testing.T) {
+		client := &kube_fake.FakeKubeClient{
+			TestKubernetesInterface: k8s_fake.NewSimpleClientset(
+				fixPod("app-5d4f-abcde", "app", corev1.PodRunning, true, 0, nil),
+				fixPod("app-5d4f-fghij", "app", corev1.PodRunning, true, 0, nil),
+				fixPod("other-5d4f-abcde", "other", corev1.PodRunning, true, 0, nil),
+			),
+		}
+		buffer := bytes.NewBuffer([]byte{})
+
+		err := StreamLogs(context.Background(), client, buffer, bytes.NewBuffer([]byte{}), "app", "default", LogsOptions{
+			Since: time.Minute,
+		})
+		require.NoError(t, err)
+
+		// lines from pods are written concurrently
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		sort.Strings(lines)
+		require.Equal(t, []string{
+			"[app-5d4f-abcde] fake logs",
+			"[app-5d4f-fghij] fake logs",
+		}, lines)
+	})
+
+	t.Run("follow logs of new pods", func(t *testing.T) {
+		running := &corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+		clientset := k8s_fake.NewSimpleClientset(
+			fixPod("app-5d4f-abcde", "app", corev1.PodRunning, true, 0, running),
+			fixPod("app-5d4f-fghij", "app", corev1.PodPending, false, 0, nil),
+		)
+		client := &kube_fake.FakeKubeClient{
+			TestKubernetesInterface: clientset,
+		}
+		buffer := &syncBuffer{}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		errChan := make(chan error)
+		go func() {
+			errChan <- StreamLogs(ctx, client, buffer, bytes.NewBuffer([]byte{}), "app", "default", LogsOptions{
+				Follow: true,
+			})
+		}()
+		// pods are changed after the watch is started
+		require.Eventually(t, func() bool {
+			return slices.ContainsFunc(clientset.Actions(), func(action k8s_testing.Action) bool {
+				return action.GetVerb() == "watch"
+			})
+		}, time.Second, 10*time.Millisecond)
+
+		// the pending pod starts and a new pod is created
+		pods := client.Static().CoreV1().Pods("default")
+		_, err := pods.Update(ctx, fixPod("app-5d4f-fghij", "app", corev1.PodRunning, true, 0, running), metav1.UpdateOptions{})
+		require.NoError(t, err)
+		_, err = pods.Create(ctx, fixPod("app-5d4f-klmno", "app", corev1.PodRunning, true, 0, running), metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			return strings.Contains(buffer.String(), "[app-5d4f-fghij] fake logs") &&
+				strings.Contains(buffer.String(), "[app-5d4f-klmno] fake logs")
+		}, time.Second, 10*time.Millisecond)
+
+		cancel()
+		require.NoError(t, <-errChan)
+		require.Equal(t, 3, strings.Count(buffer.String(), "fake logs"))
+	})
+
+	t.Run("skip pods without previous container", func(t *testing.T) {
+		client := &kube_fake.FakeKubeClient{
+			TestKubernetesInterface: k8s_fake.NewSimpleClientset(
+				fixPod("app-5d4f-abcde", "app", corev1.PodRunning, true, 0, nil),
+				fixPod("app-5d4f-fghij", "app", corev1.PodRunning, false, 2, nil),
+			),
+		}
+		buffer := bytes.NewBuffer([]byte{})
+
+		err := StreamLogs(context.Background(), client, buffer, bytes.NewBuffer([]byte{}), "app", "default", LogsOptions{
+			Previous: true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "[app-5d4f-fghij] fake logs\n", buffer.String())
+	})
+
+	t.Run("no pods with previous container", func(t *testing.T) {
+		client := &kube_fake.FakeKubeClient{
+			TestKubernetesInterface: k8s_fake.NewSimpleClientset(
+				fixPod("app-5d4f-abcde", "app", corev1.PodRunning, true, 0, nil),
+			),
+		}
+
+		err := StreamLogs(context.Background(), client, bytes.NewBuffer([]byte{}), bytes.NewBuffer([]byte{}), "app", "default", LogsOptions{
+			Previous: true,
+		})
+		require.ErrorIs(t, err, ErrNoPreviousContainers)
+	})
+
+	t.Run("no pods", func(t *testing.T) {
+		client := &kube_fake.FakeKubeClient{
+			TestKubernetesInterface: k8s_fake.NewSimpleClientset(),
+		}
+
+		err := StreamLogs(context.Background(), client, bytes.NewBuffer([]byte{}), bytes.NewBuffer([]byte{}), "app", "default", LogsOptions{})
+		require.ErrorIs(t, err, ErrNoPods)
+	})
+}
+
+func Test_buildPodLogOptions(t *testing.T) {
+	t.Run("round since up to whole seconds", func(t *testing.T) {
+		logOpts := buildPodLogOptions("app", LogsOptions{Since: 1500 * time.Millisecond})
+		require.Equal(t, ptr.To(int64(2)), logOpts.SinceSeconds)
+
+		logOpts = buildPodLogOptions("app", LogsOptions{Since: 100 * time.Millisecond})
+		require.Equal(t, ptr.To(int64(1)), logOpts.SinceSeconds)
+	})
+
+	t.Run("no since", func(t *testing.T) {
+		logOpts := buildPodLogOptions("app", LogsOptions{})
+		require.Nil(t, logOpts.SinceSeconds)
+	})
+}
+
+// syncBuffer is a buffer safe to read while logs are written
+type syncBuffer struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (sb *syncBuffer) Write(p []byte) (int, error) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.buffer.Write(p)
+}
+
+func (sb *syncBuffer) String() string {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.buffer.String()
+}
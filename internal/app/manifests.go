@@ -0,0 +1,81 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// RenderManifests renders manifests in the given format
+// the yaml format with every manifest in a separate document is used if the format is empty
+// the json format renders manifests as items of the v1 List
+func RenderManifests(writer io.Writer, manifests []unstructured.Unstructured, format types.Format) error {
+	if format == types.JSONFormat {
+		items := []interface{}{}
+		for _, manifest := range manifests {
+			items = append(items, manifest.Object)
+		}
+
+		return types.RenderJSON(writer, map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "List",
+			"items":      items,
+		})
+	}
+
+	encoder := yaml.NewEncoder(writer)
+	encoder.SetIndent(2)
+	defer encoder.Close()
+
+	for _, manifest := range manifests {
+		err := encoder.Encode(manifest.Object)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SaveManifests writes every manifest to the '<kind>-<name>.<format>' file in the directory
+func SaveManifests(dir string, manifests []unstructured.Unstructured, format types.Format) error {
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	extension := "yaml"
+	if format == types.JSONFormat {
+		extension = "json"
+	}
+
+	for _, manifest := range manifests {
+		fileName := fmt.Sprintf("%s-%s.%s", strings.ToLower(manifest.GetKind()), manifest.GetName(), extension)
+		file, err := os.Create(filepath.Join(dir, fileName))
+		if err != nil {
+			return err
+		}
+
+		err = renderManifest(file, manifest, format)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func renderManifest(writer io.Writer, manifest unstructured.Unstructured, format types.Format) error {
+	if format == types.JSONFormat {
+		return types.RenderJSON(writer, manifest.Object)
+	}
+
+	return RenderManifests(writer, []unstructured.Unstructured{manifest}, format)
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRenderManifests(t *testing.T) {
+	manifests := []unstructured.Unstructured{
+		*fixUnstructured("apps/v1", "Deployment", "app", nil),
+		*fixUnstructured("v1", "Service", "app", nil),
+	}
+
+	t.Run("render yaml", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+		err := RenderManifests(buffer, manifests, types.DefaultFormat)
+		require.NoError(t, err)
+		require.Equal(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: default
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+  namespace: default
+`, buffer.String())
+	})
+
+	t.Run("render json list", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+		err := RenderManifests(buffer, manifests[1:], types.JSONFormat)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+  "apiVersion": "v1",
+  "kind": "List",
+  "items": [
+    {"apiVersion": "v1", "kind": "Service", "metadata": {"name": "app", "namespace": "default"}}
+  ]
+}`, buffer.String())
+	})
+}
+
+func TestSaveManifests(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "manifests")
+	manifests := []unstructured.Unstructured{
+		*fixUnstructured("apps/v1", "Deployment", "app", nil),
+		*fixUnstructured("v1", "ConfigMap", "app-env", nil),
+	}
+
+	err := SaveManifests(dir, manifests, types.YAMLFormat)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "deployment-app.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\n  namespace: default\n", string(data))
+
+	err = SaveManifests(dir, manifests, types.JSONFormat)
+	require.NoError(t, err)
+
+	data, err = os.ReadFile(filepath.Join(dir, "configmap-app-env.json"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "app-env", "namespace": "default"}}`, string(data))
+}
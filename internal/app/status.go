@@ -0,0 +1,324 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/kyma-project/cli.v3/internal/kube"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	k8s_types "k8s.io/apimachinery/pkg/types"
+)
+
+// maxStatusEvents limits number of the most recent events in the status
+const maxStatusEvents = 10
+
+type RolloutState string
+
+const (
+	RolloutProgressing RolloutState = "Progressing"
+	RolloutComplete    RolloutState = "Complete"
+	RolloutFailed      RolloutState = "Failed"
+)
+
+// Status describes the rollout of the app deployment with its pods and recent events
+type Status struct {
+	Name              string       `json:"name" yaml:"name"`
+	Namespace         string       `json:"namespace" yaml:"namespace"`
+	Image             string       `json:"image" yaml:"image"`
+	Rollout           RolloutState `json:"rollout" yaml:"rollout"`
+	RolloutMessage    string       `json:"rolloutMessage,omitempty" yaml:"rolloutMessage,omitempty"`
+	Replicas          int32        `json:"replicas" yaml:"replicas"`
+	UpdatedReplicas   int32        `json:"updatedReplicas" yaml:"updatedReplicas"`
+	ReadyReplicas     int32        `json:"readyReplicas" yaml:"readyReplicas"`
+	AvailableReplicas int32        `json:"availableReplicas" yaml:"availableReplicas"`
+	Pods              []PodStatus  `json:"pods" yaml:"pods"`
+	Events            []Event      `json:"events" yaml:"events"`
+}
+
+type PodStatus struct {
+	Name     string `json:"name" yaml:"name"`
+	Phase    string `json:"phase" yaml:"phase"`
+	Reason   string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Ready    string `json:"ready" yaml:"ready"`
+	Restarts int32  `json:"restarts" yaml:"restarts"`
+}
+
+type Event struct {
+	Type     string    `json:"type" yaml:"type"`
+	Reason   string    `json:"reason" yaml:"reason"`
+	Object   string    `json:"object" yaml:"object"`
+	Message  string    `json:"message" yaml:"message"`
+	LastSeen time.Time `json:"lastSeen" yaml:"lastSeen"`
+}
+
+// GetStatus returns status of the app built from its deployment, pods and events
+func GetStatus(ctx context.Context, client kube.Client, name, namespace string) (*Status, error) {
+	deployment, err := client.Static().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := ListPods(ctx, client, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	replicaSets, err := listReplicaSets(ctx, client, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	uids := []k8s_types.UID{deployment.GetUID()}
+	for _, replicaSet := range replicaSets {
+		uids = append(uids, replicaSet.GetUID())
+	}
+	for _, pod := range pods {
+		uids = append(uids, pod.GetUID())
+	}
+
+	events, err := listEvents(ctx, client, namespace, uids...)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{
+		Name:              name,
+		Namespace:         namespace,
+		Replicas:          deployment.Status.Replicas,
+		UpdatedReplicas:   deployment.Status.UpdatedReplicas,
+		ReadyReplicas:     deployment.Status.ReadyReplicas,
+		AvailableReplicas: deployment.Status.AvailableReplicas,
+		Pods:              []PodStatus{},
+		Events:            getAppEvents(events),
+	}
+	if deployment.Spec.Replicas != nil {
+		status.Replicas = *deployment.Spec.Replicas
+	}
+	if containers := deployment.Spec.Template.Spec.Containers; len(containers) > 0 {
+		status.Image = containers[0].Image
+	}
+	status.Rollout, status.RolloutMessage = GetRolloutState(deployment)
+
+	for _, pod := range pods {
+		status.Pods = append(status.Pods, getPodStatus(pod))
+	}
+
+	return status, nil
+}
+
+// ListPods returns pods selected by the app deployment
+func ListPods(ctx context.Context, client kube.Client, deployment *appsv1.Deployment) ([]corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := client.Static().CoreV1().Pods(deployment.GetNamespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pods.Items, nil
+}
+
+// listReplicaSets returns replica sets controlled by the app deployment
+func listReplicaSets(ctx context.Context, client kube.Client, deployment *appsv1.Deployment) ([]appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	replicaSets, err := client.Static().AppsV1().ReplicaSets(deployment.GetNamespace()).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	controlled := []appsv1.ReplicaSet{}
+	for _, replicaSet := range replicaSets.Items {
+		if metav1.IsControlledBy(&replicaSet, deployment) {
+			controlled = append(controlled, replicaSet)
+		}
+	}
+
+	return controlled, nil
+}
+
+// listEvents returns events involving objects with the given UIDs
+// objects are matched by UID so events of other apps with a similar name are not included
+func listEvents(ctx context.Context, client kube.Client, namespace string, uids ...k8s_types.UID) ([]corev1.Event, error) {
+	events := []corev1.Event{}
+	for _, uid := range uids {
+		list, err := client.Static().CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("involvedObject.uid", string(uid)).String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range list.Items {
+			if event.InvolvedObject.UID == uid {
+				events = append(events, event)
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// GetRolloutState returns state of the deployment rollout with a message describing its progress
+// it follows rules used by the 'kubectl rollout status' command
+func GetRolloutState(deployment *appsv1.Deployment) (RolloutState, string) {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return RolloutProgressing, "waiting for the deployment spec update to be observed"
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return RolloutFailed, condition.Message
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	if deployment.Status.UpdatedReplicas < replicas {
+		return RolloutProgressing, fmt.Sprintf("%d of %d new replicas updated", deployment.Status.UpdatedReplicas, replicas)
+	}
+	if deployment.Status.Replicas > deployment.Status.UpdatedReplicas {
+		return RolloutProgressing, fmt.Sprintf("%d old replicas pending termination", deployment.Status.Replicas-deployment.Status.UpdatedReplicas)
+	}
+	if deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas {
+		return RolloutProgressing, fmt.Sprintf("%d of %d updated replicas available", deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas)
+	}
+
+	return RolloutComplete, ""
+}
+
+// RenderStatus renders the app status in the given format
+func RenderStatus(writer io.Writer, status *Status, format types.Format) error {
+	return types.RenderFormat(writer, status, format, func(writer io.Writer) {
+		renderStatusText(writer, status)
+	})
+}
+
+func renderStatusText(writer io.Writer, status *Status) {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	defer tw.Flush()
+
+	fmt.Fprintf(tw, "Name:\t%s\n", status.Name)
+	fmt.Fprintf(tw, "Namespace:\t%s\n", status.Namespace)
+	fmt.Fprintf(tw, "Image:\t%s\n", status.Image)
+	if status.RolloutMessage != "" {
+		fmt.Fprintf(tw, "Rollout:\t%s (%s)\n", status.Rollout, status.RolloutMessage)
+	} else {
+		fmt.Fprintf(tw, "Rollout:\t%s\n", status.Rollout)
+	}
+	fmt.Fprintf(tw, "Replicas:\t%d desired, %d updated, %d ready, %d available\n",
+		status.Replicas, status.UpdatedReplicas, status.ReadyReplicas, status.AvailableReplicas)
+
+	fmt.Fprintf(tw, "Pods:\n")
+	if len(status.Pods) == 0 {
+		fmt.Fprintf(tw, "  -\n")
+	}
+	for _, pod := range status.Pods {
+		fmt.Fprintf(tw, "  %s\t%s\t%s ready\t%d restarts\n", pod.Name, describePodPhase(pod), pod.Ready, pod.Restarts)
+	}
+
+	fmt.Fprintf(tw, "Events:\n")
+	if len(status.Events) == 0 {
+		fmt.Fprintf(tw, "  -\n")
+	}
+	for _, event := range status.Events {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", event.Type, event.Reason, event.Object, event.Message)
+	}
+}
+
+// convert phase and reason into the format 'phase (reason)'
+func describePodPhase(pod PodStatus) string {
+	if pod.Reason == "" {
+		return pod.Phase
+	}
+
+	return fmt.Sprintf("%s (%s)", pod.Phase, pod.Reason)
+}
+
+func getPodStatus(pod corev1.Pod) PodStatus {
+	status := PodStatus{
+		Name:  pod.GetName(),
+		Phase: string(pod.Status.Phase),
+	}
+
+	ready := 0
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		status.Restarts += containerStatus.RestartCount
+		if containerStatus.Ready {
+			ready++
+		}
+		if status.Reason == "" {
+			status.Reason = getContainerReason(containerStatus)
+		}
+	}
+	status.Ready = fmt.Sprintf("%d/%d", ready, len(pod.Spec.Containers))
+
+	return status
+}
+
+// getContainerReason returns reason why the container is not running, for example 'CrashLoopBackOff'
+func getContainerReason(containerStatus corev1.ContainerStatus) string {
+	if containerStatus.State.Waiting != nil {
+		return containerStatus.State.Waiting.Reason
+	}
+	if containerStatus.State.Terminated != nil {
+		return containerStatus.State.Terminated.Reason
+	}
+	return ""
+}
+
+// getAppEvents returns the most recent of the given events sorted from the oldest one
+func getAppEvents(events []corev1.Event) []Event {
+	appEvents := []Event{}
+	for _, event := range events {
+		appEvents = append(appEvents, Event{
+			Type:     event.Type,
+			Reason:   event.Reason,
+			Object:   fmt.Sprintf("%s/%s", strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name),
+			Message:  strings.TrimSpace(event.Message),
+			LastSeen: getEventTime(event),
+		})
+	}
+
+	slices.SortStableFunc(appEvents, func(a, b Event) int {
+		return cmp.Compare(a.LastSeen.UnixNano(), b.LastSeen.UnixNano())
+	})
+
+	if len(appEvents) > maxStatusEvents {
+		return appEvents[len(appEvents)-maxStatusEvents:]
+	}
+	return appEvents
+}
+
+func getEventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
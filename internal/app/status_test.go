@@ -0,0 +1,224 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	kube_fake "github.com/kyma-project/cli.v3/internal/kube/fake"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_types "k8s.io/apimachinery/pkg/types"
+	k8s_fake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetStatus(t *testing.T) {
+	t.Run("get status", func(t *testing.T) {
+		deployment := fixDeployment("app", "app:1.0.0", 2, 1)
+		deployment.SetUID(k8s_types.UID("app-uid"))
+		deployment.Status.Replicas = 2
+		deployment.Status.UpdatedReplicas = 2
+		deployment.Status.AvailableReplicas = 1
+		replicaSet := fixRolloutReplicaSet(deployment, "1", "5d4f")
+		replicaSet.SetUID(k8s_types.UID("app-5d4f-uid"))
+		client := &kube_fake.FakeKubeClient{
+			TestKubernetesInterface: k8s_fake.NewSimpleClientset(
+				deployment,
+				fixPod("app-5d4f-abcde", "app", corev1.PodRunning, true, 0, nil),
+				fixPod("app-5d4f-fghij", "app", corev1.PodRunning, false, 4, &corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+				}),
+				fixPod("other-5d4f-abcde", "other", corev1.PodRunning, true, 0, nil),
+				replicaSet,
+				fixEvent("app-1", "Deployment", "app", "ScalingReplicaSet", 1),
+				fixEvent("app-2", "ReplicaSet", "app-5d4f", "SuccessfulCreate", 2),
+				fixEvent("app-3", "Pod", "app-5d4f-fghij", "BackOff", 3),
+				fixEvent("other-1", "Pod", "other-5d4f-abcde", "Pulled", 4),
+				// app with the name prefixed by the name of the app
+				fixEvent("app-gateway-1", "Deployment", "app-gateway", "ScalingReplicaSet", 5),
+			),
+		}
+
+		status, err := GetStatus(context.Background(), client, "app", "default")
+		require.NoError(t, err)
+		require.Equal(t, &Status{
+			Name:              "app",
+			Namespace:         "default",
+			Image:             "app:1.0.0",
+			Rollout:           RolloutProgressing,
+			RolloutMessage:    "1 of 2 updated replicas available",
+			Replicas:          2,
+			UpdatedReplicas:   2,
+			ReadyReplicas:     1,
+			AvailableReplicas: 1,
+			Pods: []PodStatus{
+				{Name: "app-5d4f-abcde", Phase: "Running", Ready: "1/1"},
+				{Name: "app-5d4f-fghij", Phase: "Running", Reason: "CrashLoopBackOff", Ready: "0/1", Restarts: 4},
+			},
+			Events: []Event{
+				{Type: "Normal", Reason: "ScalingReplicaSet", Object: "deployment/app", Message: "ScalingReplicaSet message", LastSeen: fixEventTime(1)},
+				{Type: "Normal", Reason: "SuccessfulCreate", Object: "replicaset/app-5d4f", Message: "SuccessfulCreate message", LastSeen: fixEventTime(2)},
+				{Type: "Normal", Reason: "BackOff", Object: "pod/app-5d4f-fghij", Message: "BackOff message", LastSeen: fixEventTime(3)},
+			},
+		}, status)
+	})
+
+	t.Run("app not found", func(t *testing.T) {
+		client := &kube_fake.FakeKubeClient{
+			TestKubernetesInterface: k8s_fake.NewSimpleClientset(),
+		}
+
+		_, err := GetStatus(context.Background(), client, "app", "default")
+		require.ErrorContains(t, err, "not found")
+	})
+}
+
+func TestGetRolloutState(t *testing.T) {
+	tests := []struct {
+		name        string
+		deployment  *appsv1.Deployment
+		wantState   RolloutState
+		wantMessage string
+	}{
+		{
+			name: "spec update not observed",
+			deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 1},
+			},
+			wantState:   RolloutProgressing,
+			wantMessage: "waiting for the deployment spec update to be observed",
+		},
+		{
+			name: "progress deadline exceeded",
+			deployment: &appsv1.Deployment{
+				Status: appsv1.DeploymentStatus{
+					Conditions: []appsv1.DeploymentCondition{
+						{
+							Type:    appsv1.DeploymentProgressing,
+							Reason:  "ProgressDeadlineExceeded",
+							Message: "ReplicaSet \"app-5d4f\" has timed out progressing.",
+						},
+					},
+				},
+			},
+			wantState:   RolloutFailed,
+			wantMessage: "ReplicaSet \"app-5d4f\" has timed out progressing.",
+		},
+		{
+			name: "replicas not updated",
+			deployment: &appsv1.Deployment{
+				Spec:   appsv1.DeploymentSpec{Replicas: ptr.To(int32(3))},
+				Status: appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 1},
+			},
+			wantState:   RolloutProgressing,
+			wantMessage: "1 of 3 new replicas updated",
+		},
+		{
+			name: "old replicas pending termination",
+			deployment: &appsv1.Deployment{
+				Spec:   appsv1.DeploymentSpec{Replicas: ptr.To(int32(1))},
+				Status: appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 1},
+			},
+			wantState:   RolloutProgressing,
+			wantMessage: "1 old replicas pending termination",
+		},
+		{
+			name: "rollout complete",
+			deployment: &appsv1.Deployment{
+				Spec:   appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+				Status: appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+			},
+			wantState: RolloutComplete,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, message := GetRolloutState(tt.deployment)
+			require.Equal(t, tt.wantState, state)
+			require.Equal(t, tt.wantMessage, message)
+		})
+	}
+}
+
+func TestRenderStatus(t *testing.T) {
+	status := &Status{
+		Name:           "app",
+		Namespace:      "default",
+		Image:          "app:1.0.0",
+		Rollout:        RolloutProgressing,
+		RolloutMessage: "0 of 1 updated replicas available",
+		Replicas:       1,
+		Pods: []PodStatus{
+			{Name: "app-5d4f-fghij", Phase: "Running", Reason: "CrashLoopBackOff", Ready: "0/1", Restarts: 4},
+		},
+		Events: []Event{},
+	}
+
+	buffer := bytes.NewBuffer([]byte{})
+	err := RenderStatus(buffer, status, types.DefaultFormat)
+	require.NoError(t, err)
+	require.Equal(t, `Name:       app
+Namespace:  default
+Image:      app:1.0.0
+Rollout:    Progressing (0 of 1 updated replicas available)
+Replicas:   1 desired, 0 updated, 0 ready, 0 available
+Pods:
+  app-5d4f-fghij  Running (CrashLoopBackOff)  0/1 ready  4 restarts
+Events:
+  -
+`, buffer.String())
+}
+
+func fixPod(name, app string, phase corev1.PodPhase, ready bool, restarts int32, state *corev1.ContainerState) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			UID:       k8s_types.UID(name + "-uid"),
+			Labels:    map[string]string{"app": app},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: app}},
+		},
+		Status: corev1.PodStatus{
+			Phase: phase,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: app, Ready: ready, RestartCount: restarts},
+			},
+		},
+	}
+	if state != nil {
+		pod.Status.ContainerStatuses[0].State = *state
+	}
+
+	return pod
+}
+
+func fixEvent(name, kind, objectName, reason string, minute int) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind: kind,
+			Name: objectName,
+			UID:  k8s_types.UID(objectName + "-uid"),
+		},
+		Type:          "Normal",
+		Reason:        reason,
+		Message:       fmt.Sprintf("%s message", reason),
+		LastTimestamp: metav1.NewTime(fixEventTime(minute)),
+	}
+}
+
+func fixEventTime(minute int) time.Time {
+	return time.Date(2024, 1, 1, 12, minute, 0, 0, time.UTC)
+}
@@ -0,0 +1,250 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/kube"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	revisionAnnotation = "deployment.kubernetes.io/revision"
+	// maxFailureEvents limits number of warning events reported for the failing pod
+	maxFailureEvents = 5
+	// configErrorGracePeriod is how long containers may wait for missing ConfigMaps and Secrets
+	// they may be created by other tools or controllers, like the BTP operator, shortly after the app
+	configErrorGracePeriod = time.Minute
+)
+
+// failedContainerReasons contains reasons of waiting containers that won't recover without changes in the app
+var failedContainerReasons = []string{
+	"ErrImagePull",
+	"ImagePullBackOff",
+	"InvalidImageName",
+	"CrashLoopBackOff",
+	"CreateContainerError",
+	"RunContainerError",
+}
+
+// WaitForRollout polls the app deployment until its rollout is complete or fails
+// the rollout fails when the progress deadline is exceeded or a pod of the new revision can't start
+// every observed rollout progress change is written to the writer
+func WaitForRollout(ctx context.Context, client kube.Client, writer io.Writer, name, namespace string, interval time.Duration) clierror.Error {
+	lastMessage := ""
+	var deployment *appsv1.Deployment
+	var failedPod *corev1.Pod
+	err := wait.PollUntilContextCancel(ctx, interval, true, func(ctx context.Context) (bool, error) {
+		var err error
+		deployment, err = client.Static().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		state, message := GetRolloutState(deployment)
+		if message != "" && message != lastMessage {
+			fmt.Fprintf(writer, "waiting for the rollout of the %s app: %s\n", name, message)
+			lastMessage = message
+		}
+
+		switch state {
+		case RolloutComplete:
+			return true, nil
+		case RolloutFailed:
+			return false, errors.New(message)
+		}
+
+		pods, err := listRolloutPods(ctx, client, deployment)
+		if err != nil {
+			return false, err
+		}
+
+		failedPod = findFailedPod(pods, time.Now())
+		if failedPod != nil {
+			return false, fmt.Errorf("pod %s failed to start", failedPod.GetName())
+		}
+
+		return false, nil
+	})
+	if err == nil {
+		fmt.Fprintf(writer, "app %s successfully rolled out\n", name)
+		return nil
+	}
+
+	if deployment == nil {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to wait for the %s app rollout", name)))
+	}
+
+	// the context may be already canceled after the timeout but details are still worth reporting
+	return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to wait for the %s app rollout", name),
+		describeRolloutFailure(context.WithoutCancel(ctx), client, deployment, failedPod)...))
+}
+
+// listRolloutPods returns pods of the replica set matching the current revision of the deployment
+func listRolloutPods(ctx context.Context, client kube.Client, deployment *appsv1.Deployment) ([]corev1.Pod, error) {
+	replicaSets, err := listReplicaSets(ctx, client, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := deployment.GetAnnotations()[revisionAnnotation]
+	for _, replicaSet := range replicaSets {
+		if replicaSet.GetAnnotations()[revisionAnnotation] != revision {
+			continue
+		}
+
+		podSelector, err := metav1.LabelSelectorAsSelector(replicaSet.Spec.Selector)
+		if err != nil {
+			return nil, err
+		}
+
+		pods, err := client.Static().CoreV1().Pods(deployment.GetNamespace()).List(ctx, metav1.ListOptions{
+			LabelSelector: podSelector.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return pods.Items, nil
+	}
+
+	// replica set of the current revision is not created yet
+	return []corev1.Pod{}, nil
+}
+
+// findFailedPod returns the first pod with a container that won't start
+// the missing configuration fails the pod only if it's older than the grace period
+func findFailedPod(pods []corev1.Pod, now time.Time) *corev1.Pod {
+	for i := range pods {
+		for _, containerStatus := range getContainerStatuses(pods[i]) {
+			waiting := containerStatus.State.Waiting
+			if waiting == nil {
+				continue
+			}
+
+			if slices.Contains(failedContainerReasons, waiting.Reason) ||
+				(waiting.Reason == "CreateContainerConfigError" && now.Sub(pods[i].GetCreationTimestamp().Time) > configErrorGracePeriod) {
+				return &pods[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// describeRolloutFailure returns hints describing state of the failing pod with its recent warning events
+// the first not ready pod of the current revision is described if there is no failing one
+func describeRolloutFailure(ctx context.Context, client kube.Client, deployment *appsv1.Deployment, pod *corev1.Pod) []string {
+	hints := []string{}
+	if pod == nil {
+		pods, err := listRolloutPods(ctx, client, deployment)
+		if err != nil {
+			return hints
+		}
+
+		pod = findNotReadyPod(pods)
+		if pod == nil {
+			return hints
+		}
+	}
+
+	for _, containerStatus := range getContainerStatuses(*pod) {
+		if containerStatus.Ready {
+			continue
+		}
+
+		hints = append(hints, describeContainerState(pod.GetName(), containerStatus)...)
+		hints = append(hints, getReasonHints(getContainerReason(containerStatus), pod)...)
+		if terminated := containerStatus.LastTerminationState.Terminated; terminated != nil && terminated.Reason == "OOMKilled" {
+			hints = append(hints, getReasonHints(terminated.Reason, pod)...)
+		}
+	}
+
+	events, err := listEvents(ctx, client, pod.GetNamespace(), pod.GetUID())
+	if err != nil {
+		return hints
+	}
+
+	podEvents := []Event{}
+	for _, event := range getAppEvents(events) {
+		if event.Type == corev1.EventTypeWarning {
+			podEvents = append(podEvents, event)
+		}
+	}
+	if len(podEvents) > maxFailureEvents {
+		podEvents = podEvents[len(podEvents)-maxFailureEvents:]
+	}
+	for _, event := range podEvents {
+		hints = append(hints, fmt.Sprintf("Event %s on %s: %s", event.Reason, event.Object, event.Message))
+	}
+
+	return hints
+}
+
+func findNotReadyPod(pods []corev1.Pod) *corev1.Pod {
+	for i := range pods {
+		for _, condition := range pods[i].Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status != corev1.ConditionTrue {
+				return &pods[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+func describeContainerState(podName string, containerStatus corev1.ContainerStatus) []string {
+	descriptions := []string{}
+	container := fmt.Sprintf("Container %s of the %s pod", containerStatus.Name, podName)
+	if waiting := containerStatus.State.Waiting; waiting != nil {
+		descriptions = append(descriptions, withMessage(fmt.Sprintf("%s is waiting with reason %s", container, waiting.Reason), waiting.Message))
+	}
+	if terminated := containerStatus.State.Terminated; terminated != nil {
+		descriptions = append(descriptions, withMessage(fmt.Sprintf("%s terminated with reason %s and exit code %d",
+			container, terminated.Reason, terminated.ExitCode), terminated.Message))
+	}
+	if terminated := containerStatus.LastTerminationState.Terminated; terminated != nil {
+		descriptions = append(descriptions, withMessage(fmt.Sprintf("%s last terminated with reason %s and exit code %d",
+			container, terminated.Reason, terminated.ExitCode), terminated.Message))
+	}
+
+	return descriptions
+}
+
+func getReasonHints(reason string, pod *corev1.Pod) []string {
+	switch reason {
+	case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+		return []string{"Make sure the image name is correct and the image is accessible from the cluster"}
+	case "CrashLoopBackOff", "Error":
+		return []string{fmt.Sprintf("Check logs of the crashing container using the 'kubectl logs %s -n %s --previous' command",
+			pod.GetName(), pod.GetNamespace())}
+	case "CreateContainerConfigError":
+		return []string{"Make sure ConfigMaps and Secrets used by the app exist"}
+	case "OOMKilled":
+		return []string{"Increase the memory limit using the --memory-limit flag"}
+	default:
+		return []string{}
+	}
+}
+
+func getContainerStatuses(pod corev1.Pod) []corev1.ContainerStatus {
+	return append(slices.Clone(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...)
+}
+
+func withMessage(description, message string) string {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return description
+	}
+
+	return fmt.Sprintf("%s: %s", description, message)
+}
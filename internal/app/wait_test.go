@@ -0,0 +1,187 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	kube_fake "github.com/kyma-project/cli.v3/internal/kube/fake"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8s_fake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func TestWaitForRollout(t *testing.T) {
+	t.Run("rollout complete", func(t *testing.T) {
+		deployment := fixRolloutDeployment(1, 1)
+		client := fixRolloutKubeClient(deployment, fixRolloutReplicaSet(deployment, "2", "new"))
+		buffer := bytes.NewBuffer([]byte{})
+
+		clierr := WaitForRollout(context.Background(), client, buffer, "app", "default", time.Millisecond)
+		require.Nil(t, clierr)
+		require.Equal(t, "app app successfully rolled out\n", buffer.String())
+	})
+
+	t.Run("pod of the new revision can't pull image", func(t *testing.T) {
+		deployment := fixRolloutDeployment(1, 0)
+		client := fixRolloutKubeClient(
+			deployment,
+			fixRolloutReplicaSet(deployment, "1", "old"),
+			fixRolloutReplicaSet(deployment, "2", "new"),
+			fixRolloutPod("app-old-abcde", "old", &corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+			}),
+			fixRolloutPod("app-new-abcde", "new", &corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image \"app:2.0.0\""},
+			}),
+			&corev1.Event{
+				ObjectMeta: metav1.ObjectMeta{Name: "app-new-abcde-1", Namespace: "default"},
+				InvolvedObject: corev1.ObjectReference{
+					Kind: "Pod",
+					Name: "app-new-abcde",
+					UID:  types.UID("app-new-abcde-uid"),
+				},
+				Type:          corev1.EventTypeWarning,
+				Reason:        "Failed",
+				Message:       "Failed to pull image \"app:2.0.0\": not found",
+				LastTimestamp: metav1.NewTime(fixEventTime(1)),
+			},
+		)
+		buffer := bytes.NewBuffer([]byte{})
+
+		clierr := WaitForRollout(context.Background(), client, buffer, "app", "default", time.Millisecond)
+		require.NotNil(t, clierr)
+		require.Equal(t, `Error:
+  failed to wait for the app app rollout
+
+Error Details:
+  pod app-new-abcde failed to start
+
+Hints:
+  - Container app of the app-new-abcde pod is waiting with reason ImagePullBackOff: Back-off pulling image "app:2.0.0"
+  - Make sure the image name is correct and the image is accessible from the cluster
+  - Event Failed on pod/app-new-abcde: Failed to pull image "app:2.0.0": not found
+`, clierr.String())
+		require.Equal(t, "waiting for the rollout of the app app: 0 of 1 updated replicas available\n", buffer.String())
+	})
+
+	t.Run("progress deadline exceeded", func(t *testing.T) {
+		deployment := fixRolloutDeployment(1, 0)
+		deployment.Status.Conditions = []appsv1.DeploymentCondition{
+			{
+				Type:    appsv1.DeploymentProgressing,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: "ReplicaSet \"app-new\" has timed out progressing.",
+			},
+		}
+		client := fixRolloutKubeClient(deployment)
+		buffer := bytes.NewBuffer([]byte{})
+
+		clierr := WaitForRollout(context.Background(), client, buffer, "app", "default", time.Millisecond)
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "ReplicaSet \"app-new\" has timed out progressing.")
+	})
+
+	t.Run("context timeout", func(t *testing.T) {
+		deployment := fixRolloutDeployment(1, 0)
+		pod := fixRolloutPod("app-new-abcde", "new", &corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1},
+		})
+		pod.Status.ContainerStatuses[0].LastTerminationState = corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137},
+		}
+		client := fixRolloutKubeClient(deployment, fixRolloutReplicaSet(deployment, "2", "new"), pod)
+		buffer := bytes.NewBuffer([]byte{})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		clierr := WaitForRollout(ctx, client, buffer, "app", "default", time.Millisecond)
+		require.NotNil(t, clierr)
+		require.Equal(t, `Error:
+  failed to wait for the app app rollout
+
+Error Details:
+  context deadline exceeded
+
+Hints:
+  - Container app of the app-new-abcde pod terminated with reason Error and exit code 1
+  - Container app of the app-new-abcde pod last terminated with reason OOMKilled and exit code 137
+  - Check logs of the crashing container using the 'kubectl logs app-new-abcde -n default --previous' command
+  - Increase the memory limit using the --memory-limit flag
+`, clierr.String())
+	})
+}
+
+func Test_findFailedPod(t *testing.T) {
+	now := time.Now()
+
+	t.Run("wait for missing configuration in the grace period", func(t *testing.T) {
+		pod := fixRolloutPod("app-new-abcde", "new", &corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: "CreateContainerConfigError"},
+		})
+		pod.SetCreationTimestamp(metav1.NewTime(now.Add(-10 * time.Second)))
+
+		require.Nil(t, findFailedPod([]corev1.Pod{*pod}, now))
+	})
+
+	t.Run("fail on missing configuration after the grace period", func(t *testing.T) {
+		pod := fixRolloutPod("app-new-abcde", "new", &corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: "CreateContainerConfigError"},
+		})
+		pod.SetCreationTimestamp(metav1.NewTime(now.Add(-2 * time.Minute)))
+
+		failedPod := findFailedPod([]corev1.Pod{*pod}, now)
+		require.NotNil(t, failedPod)
+		require.Equal(t, "app-new-abcde", failedPod.GetName())
+	})
+}
+
+func fixRolloutKubeClient(objs ...runtime.Object) *kube_fake.FakeKubeClient {
+	return &kube_fake.FakeKubeClient{
+		TestKubernetesInterface: k8s_fake.NewSimpleClientset(objs...),
+	}
+}
+
+func fixRolloutDeployment(updatedReplicas, availableReplicas int32) *appsv1.Deployment {
+	deployment := fixDeployment("app", "app:2.0.0", 1, availableReplicas)
+	deployment.SetUID(types.UID("app-uid"))
+	deployment.SetAnnotations(map[string]string{revisionAnnotation: "2"})
+	deployment.Status.Replicas = updatedReplicas
+	deployment.Status.UpdatedReplicas = updatedReplicas
+	deployment.Status.AvailableReplicas = availableReplicas
+	return deployment
+}
+
+func fixRolloutReplicaSet(owner *appsv1.Deployment, revision, hash string) *appsv1.ReplicaSet {
+	labels := map[string]string{"app": owner.GetName(), "pod-template-hash": hash}
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "app-" + hash,
+			Namespace:   "default",
+			Labels:      labels,
+			Annotations: map[string]string{revisionAnnotation: revision},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(owner, appsv1.SchemeGroupVersion.WithKind("Deployment")),
+			},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+		},
+	}
+}
+
+func fixRolloutPod(name, hash string, state *corev1.ContainerState) *corev1.Pod {
+	pod := fixPod(name, "app", corev1.PodRunning, false, 1, state)
+	pod.Labels["pod-template-hash"] = hash
+	pod.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodReady, Status: corev1.ConditionFalse},
+	}
+	return pod
+}
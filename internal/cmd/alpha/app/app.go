@@ -14,6 +14,11 @@ func NewAppCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
 	}
 
 	cmd.AddCommand(NewAppPushCMD(kymaConfig))
+	cmd.AddCommand(NewAppListCMD(kymaConfig))
+	cmd.AddCommand(NewAppStatusCMD(kymaConfig))
+	cmd.AddCommand(NewAppLogsCMD(kymaConfig))
+	cmd.AddCommand(NewAppForwardCMD(kymaConfig))
+	cmd.AddCommand(NewAppDeleteCMD(kymaConfig))
 
 	return cmd
 }
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/cli.v3/internal/app"
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/spf13/cobra"
+)
+
+type appDeleteConfig struct {
+	*cmdcommon.KymaConfig
+
+	name      string
+	namespace string
+}
+
+func NewAppDeleteCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
+	config := appDeleteConfig{
+		KymaConfig: kymaConfig,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete the application from the Kubernetes cluster.",
+		Long:  "Use this command to delete the Deployment, Service, APIRule and generated configuration of the application.",
+		Args:  cobra.ExactArgs(1),
+
+		PreRun: func(_ *cobra.Command, args []string) {
+			config.complete(args)
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			clierror.Check(runAppDelete(&config))
+		},
+	}
+
+	cmd.Flags().StringVar(&config.namespace, "namespace", "default", "Namespace of the application")
+
+	return cmd
+}
+
+func (adc *appDeleteConfig) complete(args []string) {
+	adc.name = args[0]
+}
+
+func runAppDelete(cfg *appDeleteConfig) clierror.Error {
+	client, clierr := cfg.GetKubeClientWithClierr()
+	if clierr != nil {
+		return clierr
+	}
+
+	deleted, err := app.Delete(cfg.Ctx, client, cfg.name, cfg.namespace)
+	for _, resource := range deleted {
+		fmt.Printf("Deleted %s\n", resource)
+	}
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to delete application"))
+	}
+
+	if len(deleted) == 0 {
+		return clierror.New(fmt.Sprintf("application %s/%s not found", cfg.namespace, cfg.name),
+			"Use the 'kyma alpha app list' command to list applications in the namespace")
+	}
+
+	return nil
+}
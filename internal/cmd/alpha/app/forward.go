@@ -0,0 +1,117 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/kyma-project/cli.v3/internal/app"
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type appForwardConfig struct {
+	*cmdcommon.KymaConfig
+
+	name       string
+	namespace  string
+	localPort  int32
+	remotePort int32
+}
+
+func NewAppForwardCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
+	config := appForwardConfig{
+		KymaConfig: kymaConfig,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "forward <name> [<local-port>:<remote-port>]",
+		Short: "Forward a local port to the application.",
+		Long: `Use this command to forward connections from a local port to a ready pod of the application or the service.
+The pod is selected again when it restarts. The remote port is taken from the service or the application container if not provided.`,
+		Args: cobra.RangeArgs(1, 2),
+
+		PreRun: func(_ *cobra.Command, args []string) {
+			clierror.Check(config.complete(args))
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			clierror.Check(runAppForward(&config))
+		},
+	}
+
+	cmd.Flags().StringVar(&config.namespace, "namespace", "default", "Namespace of the application")
+
+	return cmd
+}
+
+func (afc *appForwardConfig) complete(args []string) clierror.Error {
+	afc.name = args[0]
+	if len(args) < 2 {
+		return nil
+	}
+
+	var err error
+	localPort, remotePort, found := strings.Cut(args[1], ":")
+	if !found {
+		// the same port is used locally and remotely
+		remotePort = localPort
+	}
+
+	afc.localPort, err = parsePort(localPort)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to parse local port '%s'", localPort),
+			"Use the format '<local-port>:<remote-port>' or '<port>'"))
+	}
+
+	afc.remotePort, err = parsePort(remotePort)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to parse remote port '%s'", remotePort),
+			"Use the format '<local-port>:<remote-port>' or '<port>'"))
+	}
+
+	return nil
+}
+
+// parsePort parses port in the range 1-65535, empty value means the port is not set
+func parsePort(value string) (int32, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	port, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if port < 1 || port > 65535 {
+		return 0, errors.New("port must be in the range 1-65535")
+	}
+
+	return int32(port), nil
+}
+
+func runAppForward(cfg *appForwardConfig) clierror.Error {
+	client, clierr := cfg.GetKubeClientWithClierr()
+	if clierr != nil {
+		return clierr
+	}
+
+	err := app.Forward(cfg.Ctx, client, os.Stdout, cfg.name, cfg.namespace, app.ForwardOptions{
+		LocalPort:  cfg.localPort,
+		RemotePort: cfg.remotePort,
+	})
+	if apierrors.IsNotFound(err) {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("application or service %s/%s not found", cfg.namespace, cfg.name),
+			"Use the 'kyma alpha app list' command to list applications in the namespace"))
+	}
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to forward port to the application",
+			"Provide the remote port in the format '<local-port>:<remote-port>'",
+			"Make sure the local port is not used by another process"))
+	}
+
+	return nil
+}
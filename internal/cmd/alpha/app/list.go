@@ -0,0 +1,58 @@
+package app
+
+import (
+	"os"
+
+	"github.com/kyma-project/cli.v3/internal/app"
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/spf13/cobra"
+)
+
+type appListConfig struct {
+	*cmdcommon.KymaConfig
+
+	namespace    string
+	outputFormat types.Format
+}
+
+func NewAppListCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
+	config := appListConfig{
+		KymaConfig: kymaConfig,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List applications pushed to the Kubernetes cluster.",
+		Long:  "Use this command to list applications pushed to the namespace with their image, ready replicas, service port and exposed URL.",
+
+		Run: func(_ *cobra.Command, _ []string) {
+			clierror.Check(runAppList(&config))
+		},
+	}
+
+	cmd.Flags().StringVar(&config.namespace, "namespace", "default", "Namespace of applications")
+	cmd.Flags().VarP(&config.outputFormat, "output", "o", "Output format (possible values: table, json, yaml)")
+
+	return cmd
+}
+
+func runAppList(cfg *appListConfig) clierror.Error {
+	client, clierr := cfg.GetKubeClientWithClierr()
+	if clierr != nil {
+		return clierr
+	}
+
+	apps, err := app.List(cfg.Ctx, client, cfg.namespace)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to list applications"))
+	}
+
+	err = app.RenderList(os.Stdout, apps, cfg.outputFormat)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to render applications list"))
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kyma-project/cli.v3/internal/app"
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/spf13/cobra"
+)
+
+type appLogsConfig struct {
+	*cmdcommon.KymaConfig
+
+	name      string
+	namespace string
+	follow    bool
+	since     time.Duration
+	container string
+	previous  bool
+}
+
+func NewAppLogsCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
+	config := appLogsConfig{
+		KymaConfig: kymaConfig,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "logs <name>",
+		Short: "Print logs of the application.",
+		Long:  "Use this command to print logs from all pods of the application. Every line is prefixed with the name of the pod. Pods failing to stream their logs are reported as warnings.",
+		Args:  cobra.ExactArgs(1),
+
+		PreRun: func(_ *cobra.Command, args []string) {
+			config.complete(args)
+			clierror.Check(config.validate())
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			clierror.Check(runAppLogs(&config))
+		},
+	}
+
+	cmd.Flags().StringVar(&config.namespace, "namespace", "default", "Namespace of the application")
+	cmd.Flags().BoolVarP(&config.follow, "follow", "f", false, "Stream new logs, including logs of new pods, until the command is interrupted")
+	cmd.Flags().DurationVar(&config.since, "since", 0, "Print only logs newer than the relative duration rounded up to whole seconds, for example 5s, 2m or 3h")
+	cmd.Flags().StringVar(&config.container, "container", "", "Name of the container to print logs from, the application container is used if empty")
+	cmd.Flags().BoolVar(&config.previous, "previous", false, "Print logs of the previous instance of crashed containers, pods without restarted containers are skipped")
+
+	cmd.MarkFlagsMutuallyExclusive("follow", "previous")
+
+	return cmd
+}
+
+func (alc *appLogsConfig) complete(args []string) {
+	alc.name = args[0]
+}
+
+func (alc *appLogsConfig) validate() clierror.Error {
+	if alc.since < 0 {
+		return clierror.New("since must not be negative")
+	}
+	return nil
+}
+
+func runAppLogs(cfg *appLogsConfig) clierror.Error {
+	client, clierr := cfg.GetKubeClientWithClierr()
+	if clierr != nil {
+		return clierr
+	}
+
+	err := app.StreamLogs(cfg.Ctx, client, os.Stdout, os.Stderr, cfg.name, cfg.namespace, app.LogsOptions{
+		Follow:    cfg.follow,
+		Since:     cfg.since,
+		Container: cfg.container,
+		Previous:  cfg.previous,
+	})
+	if errors.Is(err, app.ErrNoPods) {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("application %s/%s has no pods", cfg.namespace, cfg.name),
+			"Use the 'kyma alpha app status' command to check the application rollout"))
+	}
+	if errors.Is(err, app.ErrNoPreviousContainers) {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("application %s/%s has no restarted containers", cfg.namespace, cfg.name),
+			"Use the --previous flag only for containers that were restarted"))
+	}
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to print application logs",
+			"Make sure the container name is correct"))
+	}
+
+	return nil
+}
@@ -1,23 +1,41 @@
 package app
 
 import (
+	"bytes"
+	"context"
+	"encoding/csv"
 	"fmt"
 	"github.com/kyma-project/cli.v3/internal/kube"
+	"maps"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/kyma-project/cli.v3/internal/app"
 	"github.com/kyma-project/cli.v3/internal/clierror"
 	"github.com/kyma-project/cli.v3/internal/cmdcommon"
 	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
 	"github.com/kyma-project/cli.v3/internal/dockerfile"
 	"github.com/kyma-project/cli.v3/internal/kube/resources"
+	"github.com/kyma-project/cli.v3/internal/kube/rootlessdynamic"
 	"github.com/kyma-project/cli.v3/internal/registry"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 type appPushConfig struct {
 	*cmdcommon.KymaConfig
 
+	descriptorFile       string
 	name                 string
 	namespace            string
 	image                string
@@ -26,8 +44,52 @@ type appPushConfig struct {
 	containerPort        types.NullableInt64
 	istioInject          types.NullableBool
 	expose               bool
+	jwtIssuer            string
+	jwtJwksURI           string
+	jwtRequiredScopes    []string
+	extAuthorizers       []string
+	exposeRulesFile      string
+	replicas             int32
+	cpuRequest           string
+	cpuLimit             string
+	memoryRequest        string
+	memoryLimit          string
+	livenessProbe        types.Probe
+	readinessProbe       types.Probe
+	command              []string
+	args                 []string
+	envs                 []string
+	envFile              string
+	secretEnvFile        string
+	envFromConfigmaps    []string
+	envFromSecrets       []string
+	mountConfigmaps      []string
+	mountSecrets         []string
+	wait                 bool
+	timeout              time.Duration
+	domain               string
+	dryRun               bool
+	serverDryRun         bool
+	outputFormat         types.Format
+	outputDir            string
+	bind                 []string
+	bindFormat           string
+
+	resources       corev1.ResourceRequirements
+	env             map[string]string
+	configMapMounts []resources.Mount
+	secretMounts    []resources.Mount
+	bindings        []app.Binding
 }
 
+const (
+	bindFormatFiles = "files"
+	bindFormatVCAP  = "vcap"
+
+	// dryRunVCAPServices is the VCAP_SERVICES value rendered in the dry run before services are bound
+	dryRunVCAPServices = "{}"
+)
+
 func NewAppPushCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
 	config := appPushConfig{
 		KymaConfig: kymaConfig,
@@ -38,7 +100,8 @@ func NewAppPushCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
 		Short: "Push the application to the Kubernetes cluster.",
 		Long:  "Use this command to push the application to the Kubernetes cluster.",
 
-		PreRun: func(_ *cobra.Command, args []string) {
+		PreRun: func(cmd *cobra.Command, args []string) {
+			clierror.Check(config.loadDescriptor(cmd.Flags()))
 			clierror.Check(config.complete())
 			clierror.Check(config.validate())
 		},
@@ -47,6 +110,7 @@ func NewAppPushCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringVarP(&config.descriptorFile, "file", "f", "", fmt.Sprintf("Path to the app descriptor file, for example %s, flags override values from the file", app.DefaultDescriptorFile))
 	cmd.Flags().StringVar(&config.name, "name", "", "Name of the app")
 	cmd.Flags().StringVar(&config.namespace, "namespace", "default", "Namespace where app should be deployed")
 	cmd.Flags().StringVar(&config.image, "image", "", "Name of the image to deploy")
@@ -55,18 +119,192 @@ func NewAppPushCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
 	cmd.Flags().Var(&config.containerPort, "container-port", "Port on which the application will be exposed")
 	cmd.Flags().Var(&config.istioInject, "istio-inject", "Enable Istio for the app")
 	cmd.Flags().BoolVar(&config.expose, "expose", false, "Creates an ApiRule for the app")
+	cmd.Flags().StringVar(&config.jwtIssuer, "jwt-issuer", "", "Issuer of JWT tokens required to access the exposed app")
+	cmd.Flags().StringVar(&config.jwtJwksURI, "jwt-jwks-uri", "", "URI of the JSON Web Key Set used to verify JWT tokens of the issuer")
+	cmd.Flags().StringSliceVar(&config.jwtRequiredScopes, "jwt-required-scopes", []string{}, "Scopes required in JWT tokens to access the exposed app")
+	cmd.Flags().StringSliceVar(&config.extAuthorizers, "ext-auth-authorizer", []string{}, "Name of the external authorizer configured in Istio, for example an OAuth2 introspection provider, used to authorize requests to the exposed app")
+	cmd.Flags().StringVar(&config.exposeRulesFile, "expose-rules-file", "", "Path to the yaml file with per-path and per-method rules of the APIRule in the v2alpha1 format")
+	cmd.Flags().Int32Var(&config.replicas, "replicas", 1, "Number of replicas of the app")
+	cmd.Flags().StringVar(&config.cpuRequest, "cpu-request", "50m", "CPU request of the app container")
+	cmd.Flags().StringVar(&config.cpuLimit, "cpu-limit", "100m", "CPU limit of the app container, empty value removes the limit")
+	cmd.Flags().StringVar(&config.memoryRequest, "memory-request", "64Mi", "Memory request of the app container")
+	cmd.Flags().StringVar(&config.memoryLimit, "memory-limit", "128Mi", "Memory limit of the app container, empty value removes the limit")
+	cmd.Flags().Var(&config.livenessProbe, "liveness-probe", "Liveness probe of the app container in the format 'http:[<port>][<path>]' or 'tcp:[<port>]', the container port is used if port is empty")
+	cmd.Flags().Var(&config.readinessProbe, "readiness-probe", "Readiness probe of the app container in the format 'http:[<port>][<path>]' or 'tcp:[<port>]', the container port is used if port is empty")
+	cmd.Flags().StringSliceVar(&config.command, "command", []string{}, "Command overriding the image entrypoint")
+	cmd.Flags().StringSliceVar(&config.args, "args", []string{}, "Arguments of the app container command")
+	cmd.Flags().StringArrayVar(&config.envs, "env", []string{}, "Environment variable of the app in the format 'KEY=VALUE', can be used multiple times")
+	cmd.Flags().StringVar(&config.envFile, "env-file", "", "Path to the .env file with variables stored in the ConfigMap generated for the app")
+	cmd.Flags().StringVar(&config.secretEnvFile, "secret-env-file", "", "Path to the .env file with variables stored in the Secret generated for the app")
+	cmd.Flags().StringSliceVar(&config.envFromConfigmaps, "env-from-configmap", []string{}, "Name of the ConfigMap with environment variables of the app")
+	cmd.Flags().StringSliceVar(&config.envFromSecrets, "env-from-secret", []string{}, "Name of the Secret with environment variables of the app")
+	cmd.Flags().StringSliceVar(&config.mountConfigmaps, "mount-configmap", []string{}, "ConfigMap mounted as files in the format 'name:/path'")
+	cmd.Flags().StringSliceVar(&config.mountSecrets, "mount-secret", []string{}, "Secret mounted as files in the format 'name:/path'")
+	cmd.Flags().BoolVar(&config.wait, "wait", false, "Wait until the app rollout is complete and report failing pods")
+	cmd.Flags().DurationVar(&config.timeout, "timeout", 5*time.Minute, "Maximum time to wait for the app rollout and service bindings")
+	cmd.Flags().StringVar(&config.domain, "domain", "", "Domain of the exposed app host, the cluster domain from the Istio gateway is used if empty")
+	cmd.Flags().BoolVar(&config.dryRun, "dry-run", false, "Render manifests of the app without contacting the cluster")
+	cmd.Flags().BoolVar(&config.serverDryRun, "server-dry-run", false, "Validate manifests of the app on the cluster with the DryRun: All option and render them")
+	cmd.Flags().VarP(&config.outputFormat, "output", "o", "Output format of rendered manifests (possible values: yaml, json)")
+	cmd.Flags().StringVar(&config.outputDir, "output-dir", "", "Directory where rendered manifests are saved instead of printing them")
+	cmd.Flags().StringSliceVar(&config.bind, "bind", []string{}, "BTP service instance bound to the app in the format '<serviceInstance>[:bindingName]', the binding name is '<name>-<serviceInstance>' if empty")
+	cmd.Flags().StringVar(&config.bindFormat, "bind-format", bindFormatFiles, fmt.Sprintf("Format of bound service credentials (possible values: files, vcap), files are mounted under %s/<bindingName> and vcap sets the VCAP_SERVICES variable", app.BindingsRoot))
 
-	_ = cmd.MarkFlagRequired("name")
-	cmd.MarkFlagsMutuallyExclusive("image", "dockerfile")
-	cmd.MarkFlagsMutuallyExclusive("image", "dockerfile-context")
-	cmd.MarkFlagsOneRequired("image", "dockerfile")
+	// flags set in the descriptor file are validated after merging it with the validate method
+	cmd.MarkFlagsMutuallyExclusive("dry-run", "server-dry-run")
+	for _, dryRunFlag := range []string{"dry-run", "server-dry-run"} {
+		// rollout can't be observed in the dry run
+		cmd.MarkFlagsMutuallyExclusive(dryRunFlag, "wait")
+	}
 
 	return cmd
 }
 
+// descriptorFlagGroups groups flags configuring the same setting in mutually exclusive ways
+// flags of the group aren't set from the app descriptor file if any of them is set in the command line
+var descriptorFlagGroups = [][]string{
+	{"image", "dockerfile", "dockerfile-context"},
+	{"jwt-issuer", "jwt-jwks-uri", "jwt-required-scopes", "ext-auth-authorizer", "expose-rules-file"},
+}
+
+// loadDescriptor sets flags from the app descriptor file
+// values of flags set in the command line and flags of their groups are not overridden
+func (apc *appPushConfig) loadDescriptor(flags *pflag.FlagSet) clierror.Error {
+	if apc.descriptorFile == "" {
+		return nil
+	}
+
+	descriptor, err := app.ReadDescriptor(apc.descriptorFile)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to read app descriptor file %s", apc.descriptorFile),
+			"Make sure the file exists and contains only fields supported by the app descriptor"))
+	}
+
+	// flags set from the descriptor are marked as changed so the command line flags are collected first
+	skippedFlags := changedFlagGroups(flags)
+	for _, flagValues := range descriptorFlagValues(descriptor) {
+		if slices.Contains(skippedFlags, flagValues.flag) {
+			continue
+		}
+
+		values := flagValues.values
+		if flags.Lookup(flagValues.flag).Value.Type() == "stringSlice" {
+			// slice flags split values by commas so values are passed together in the CSV format
+			values, err = joinCSV(values)
+			if err != nil {
+				return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to set %s from app descriptor file %s", flagValues.flag, apc.descriptorFile)))
+			}
+		}
+
+		for _, value := range values {
+			err = flags.Set(flagValues.flag, value)
+			if err != nil {
+				return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to set %s from app descriptor file %s", flagValues.flag, apc.descriptorFile)))
+			}
+		}
+	}
+
+	return nil
+}
+
+// changedFlagGroups returns flags set in the command line with all flags of their groups
+func changedFlagGroups(flags *pflag.FlagSet) []string {
+	changed := []string{}
+	flags.Visit(func(flag *pflag.Flag) {
+		changed = append(changed, flag.Name)
+	})
+
+	for _, group := range descriptorFlagGroups {
+		if slices.ContainsFunc(group, flags.Changed) {
+			changed = append(changed, group...)
+		}
+	}
+
+	return changed
+}
+
+func joinCSV(values []string) ([]string, error) {
+	buffer := &bytes.Buffer{}
+	writer := csv.NewWriter(buffer)
+	err := writer.Write(values)
+	if err != nil {
+		return nil, err
+	}
+	writer.Flush()
+
+	return []string{strings.TrimSuffix(buffer.String(), "\n")}, writer.Error()
+}
+
+type descriptorFlag struct {
+	flag   string
+	values []string
+}
+
+// descriptorFlagValues maps descriptor fields to values of the push command flags
+// empty fields are skipped, list fields are set value by value like repeated flags
+func descriptorFlagValues(descriptor *app.Descriptor) []descriptorFlag {
+	flagValues := []descriptorFlag{}
+	add := func(flag string, values ...string) {
+		values = slices.DeleteFunc(values, func(value string) bool { return value == "" })
+		if len(values) > 0 {
+			flagValues = append(flagValues, descriptorFlag{flag: flag, values: values})
+		}
+	}
+
+	add("name", descriptor.Name)
+	add("namespace", descriptor.Namespace)
+	add("image", descriptor.Image)
+	add("dockerfile", descriptor.Dockerfile)
+	add("dockerfile-context", descriptor.DockerfileContext)
+	if descriptor.ContainerPort != nil {
+		add("container-port", strconv.FormatInt(*descriptor.ContainerPort, 10))
+	}
+	if descriptor.IstioInject != nil {
+		add("istio-inject", strconv.FormatBool(*descriptor.IstioInject))
+	}
+	if descriptor.Replicas != nil {
+		add("replicas", strconv.FormatInt(int64(*descriptor.Replicas), 10))
+	}
+	add("command", descriptor.Command...)
+	add("args", descriptor.Args...)
+	add("cpu-request", descriptor.Resources.CPURequest)
+	add("cpu-limit", descriptor.Resources.CPULimit)
+	add("memory-request", descriptor.Resources.MemoryRequest)
+	add("memory-limit", descriptor.Resources.MemoryLimit)
+	add("liveness-probe", descriptor.LivenessProbe)
+	add("readiness-probe", descriptor.ReadinessProbe)
+
+	envs := []string{}
+	for _, key := range slices.Sorted(maps.Keys(descriptor.Env.Values)) {
+		envs = append(envs, fmt.Sprintf("%s=%s", key, descriptor.Env.Values[key]))
+	}
+	add("env", envs...)
+	add("env-file", descriptor.Env.File)
+	add("secret-env-file", descriptor.Env.SecretFile)
+	add("env-from-configmap", descriptor.Env.FromConfigMaps...)
+	add("env-from-secret", descriptor.Env.FromSecrets...)
+	add("mount-configmap", descriptor.Env.MountConfigMaps...)
+	add("mount-secret", descriptor.Env.MountSecrets...)
+
+	if descriptor.Expose.Enabled {
+		add("expose", "true")
+	}
+	add("domain", descriptor.Expose.Domain)
+	add("jwt-issuer", descriptor.Expose.Jwt.Issuer)
+	add("jwt-jwks-uri", descriptor.Expose.Jwt.JwksURI)
+	add("jwt-required-scopes", descriptor.Expose.Jwt.RequiredScopes...)
+	add("ext-auth-authorizer", descriptor.Expose.ExtAuthorizers...)
+	add("expose-rules-file", descriptor.Expose.RulesFile)
+	add("bind", descriptor.Bind.Services...)
+	add("bind-format", descriptor.Bind.Format)
+
+	return flagValues
+}
+
 func (apc *appPushConfig) complete() clierror.Error {
 	var err error
 	var info os.FileInfo
+	var clierr clierror.Error
 
 	if apc.dockerfilePath != "" {
 		// add /Dockerfile suffix if path is a directory
@@ -88,25 +326,198 @@ func (apc *appPushConfig) complete() clierror.Error {
 		}
 	}
 
+	apc.resources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}
+	for _, quantity := range []struct {
+		flag  string
+		value string
+		list  corev1.ResourceList
+		name  corev1.ResourceName
+	}{
+		{flag: "cpu-request", value: apc.cpuRequest, list: apc.resources.Requests, name: corev1.ResourceCPU},
+		{flag: "cpu-limit", value: apc.cpuLimit, list: apc.resources.Limits, name: corev1.ResourceCPU},
+		{flag: "memory-request", value: apc.memoryRequest, list: apc.resources.Requests, name: corev1.ResourceMemory},
+		{flag: "memory-limit", value: apc.memoryLimit, list: apc.resources.Limits, name: corev1.ResourceMemory},
+	} {
+		if quantity.value == "" {
+			continue
+		}
+
+		value, err := resource.ParseQuantity(quantity.value)
+		if err != nil {
+			return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to parse %s value '%s'", quantity.flag, quantity.value),
+				"Use Kubernetes quantity format, for example '500m' for CPU or '512Mi' for memory"))
+		}
+		quantity.list[quantity.name] = value
+	}
+
+	apc.env = map[string]string{}
+	for _, env := range apc.envs {
+		key, value, found := strings.Cut(env, "=")
+		if !found || key == "" {
+			return clierror.New(fmt.Sprintf("failed to parse env value '%s'", env), "Use the format 'KEY=VALUE'")
+		}
+		apc.env[key] = value
+	}
+
+	apc.configMapMounts, clierr = parseMounts("mount-configmap", apc.mountConfigmaps)
+	if clierr != nil {
+		return clierr
+	}
+
+	apc.secretMounts, clierr = parseMounts("mount-secret", apc.mountSecrets)
+	if clierr != nil {
+		return clierr
+	}
+
+	apc.bindings, clierr = parseBindings(apc.name, apc.bind)
+	if clierr != nil {
+		return clierr
+	}
+
 	return nil
 }
 
+func parseMounts(flag string, values []string) ([]resources.Mount, clierror.Error) {
+	mounts := []resources.Mount{}
+	for _, value := range values {
+		name, path, found := strings.Cut(value, ":")
+		if !found || name == "" || !strings.HasPrefix(path, "/") {
+			return nil, clierror.New(fmt.Sprintf("failed to parse %s value '%s'", flag, value), "Use the format 'name:/path'")
+		}
+		mounts = append(mounts, resources.Mount{Name: name, Path: path})
+	}
+
+	return mounts, nil
+}
+
+// parseBindings parses service instances in the format '<serviceInstance>[:bindingName]'
+func parseBindings(appName string, values []string) ([]app.Binding, clierror.Error) {
+	bindings := []app.Binding{}
+	for _, value := range values {
+		instance, name, found := strings.Cut(value, ":")
+		if instance == "" || (found && name == "") {
+			return nil, clierror.New(fmt.Sprintf("failed to parse bind value '%s'", value), "Use the format '<serviceInstance>[:bindingName]'")
+		}
+		if name == "" {
+			name = fmt.Sprintf("%s-%s", appName, instance)
+		}
+		bindings = append(bindings, app.Binding{Instance: instance, Name: name})
+	}
+
+	return bindings, nil
+}
+
 func (apc *appPushConfig) validate() clierror.Error {
+	if apc.name == "" {
+		return clierror.New("name is required", "Use the --name flag or set the name in the app descriptor file")
+	}
+	if (apc.image == "") == (apc.dockerfilePath == "") {
+		return clierror.New("exactly one of image or dockerfile is required")
+	}
+	if apc.image != "" && apc.dockerfileSrcContext != "" {
+		return clierror.New("dockerfile-context can be used only with dockerfile")
+	}
+	if (apc.dryRun || apc.serverDryRun) && apc.dockerfilePath != "" {
+		return clierror.New("dockerfile can't be used in the dry run because the image is pushed to the in-cluster registry",
+			"Use the --image flag to render manifests of the already built image")
+	}
+	if (apc.jwtIssuer == "") != (apc.jwtJwksURI == "") {
+		return clierror.New("jwt-issuer and jwt-jwks-uri must be set together")
+	}
+	if apc.exposeRulesFile != "" && (apc.jwtIssuer != "" || len(apc.jwtRequiredScopes) > 0 || len(apc.extAuthorizers) > 0) {
+		return clierror.New("expose-rules-file can't be used with jwt and ext-auth-authorizer flags",
+			"Configure access strategies of rules in the rules file")
+	}
 	if apc.expose && apc.containerPort.Value == nil {
 		return clierror.New("container-port is required when expose is enabled")
 	}
+	if !apc.expose && (apc.jwtIssuer != "" || len(apc.extAuthorizers) > 0 || apc.exposeRulesFile != "") {
+		return clierror.New("expose is required to configure the APIRule authorization")
+	}
+	if len(apc.jwtRequiredScopes) > 0 && apc.jwtIssuer == "" {
+		return clierror.New("jwt-issuer is required when jwt-required-scopes is set")
+	}
+	if !apc.dryRun && !apc.serverDryRun && (apc.outputFormat != types.DefaultFormat || apc.outputDir != "") {
+		return clierror.New("output and output-dir can be used only with dry-run or server-dry-run")
+	}
+	if apc.outputFormat == types.TableFormat {
+		return clierror.New("table output format is not supported for manifests", "Use yaml or json output format")
+	}
+	if apc.dryRun && apc.expose && apc.domain == "" {
+		return clierror.New("domain is required to render the APIRule in the dry run",
+			"Use the --domain flag to set the domain of the app host")
+	}
+	if apc.wait && apc.timeout <= 0 {
+		return clierror.New("timeout must be greater than zero when wait is enabled")
+	}
+	if len(apc.bindings) > 0 && apc.timeout <= 0 {
+		return clierror.New("timeout must be greater than zero when services are bound")
+	}
+	if apc.bindFormat != bindFormatFiles && apc.bindFormat != bindFormatVCAP {
+		return clierror.New(fmt.Sprintf("invalid bind-format value '%s'", apc.bindFormat), "Use one of the following values: files, vcap")
+	}
+	if apc.replicas < 0 {
+		return clierror.New("replicas must not be negative")
+	}
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		request, hasRequest := apc.resources.Requests[name]
+		limit, hasLimit := apc.resources.Limits[name]
+		if hasRequest && hasLimit && request.Cmp(limit) > 0 {
+			return clierror.New(fmt.Sprintf("%s request %s is greater than %s limit %s", name, request.String(), name, limit.String()),
+				fmt.Sprintf("Increase the %s limit or decrease the %s request", name, name))
+		}
+	}
+	if apc.containerPort.Value == nil {
+		if apc.livenessProbe.IsSet() && apc.livenessProbe.Port == 0 {
+			return clierror.New("liveness-probe port is required when container-port is not set")
+		}
+		if apc.readinessProbe.IsSet() && apc.readinessProbe.Port == 0 {
+			return clierror.New("readiness-probe port is required when container-port is not set")
+		}
+	}
 	return nil
 }
 
+// appPushInputs contains data read from files passed to the push command
+type appPushInputs struct {
+	envFileData       map[string]string
+	secretEnvFileData map[string]string
+	apiRuleRules      []v2alpha1.Rule
+}
+
 func runAppPush(cfg *appPushConfig) clierror.Error {
-	image := cfg.image
-	imagePullSecret := ""
+	inputs, clierr := readAppPushInputs(cfg)
+	if clierr != nil {
+		return clierr
+	}
+
+	if cfg.dryRun {
+		// manifests are rendered without contacting the cluster
+		return renderAppManifests(cfg, inputs, cfg.image, cfg.domain)
+	}
 
 	client, clierr := cfg.GetKubeClientWithClierr()
 	if clierr != nil {
 		return clierr
 	}
 
+	domain := cfg.domain
+	if cfg.expose && domain == "" {
+		domain, clierr = client.Istio().GetClusterAddressFromGateway(cfg.Ctx)
+		if clierr != nil {
+			return clierror.WrapE(clierr, clierror.New("failed to get cluster address from gateway", "Make sure Istio module is installed"))
+		}
+	}
+
+	if cfg.serverDryRun {
+		return runAppPushServerDryRun(cfg, client, inputs, domain)
+	}
+
+	image := cfg.image
+	imagePullSecret := ""
 	if cfg.dockerfilePath != "" {
 		registryConfig, cliErr := registry.GetInternalConfig(cfg.Ctx, client)
 		if cliErr != nil {
@@ -120,38 +531,373 @@ func runAppPush(cfg *appPushConfig) clierror.Error {
 		imagePullSecret = registryConfig.SecretName
 	}
 
-	fmt.Printf("\nCreating deployment %s/%s\n", cfg.namespace, cfg.name)
+	owner, clierr := getAppOwner(cfg, client)
+	if clierr != nil {
+		return clierr
+	}
 
-	err := resources.CreateDeployment(cfg.Ctx, client, cfg.name, cfg.namespace, image, imagePullSecret, cfg.istioInject)
+	// configuration is applied before the deployment so new pods start with it
+	vcapServices, clierr := applyAppConfig(cfg, client, inputs, owner)
+	if clierr != nil {
+		return clierr
+	}
+
+	fmt.Printf("\nApplying deployment %s/%s\n", cfg.namespace, cfg.name)
+
+	deploymentOpts := buildDeploymentOpts(cfg, inputs, image, imagePullSecret)
+	deploymentOpts.ConfigChecksum = buildConfigChecksum(cfg, inputs, vcapServices)
+	deployment, err := resources.ApplyDeployment(cfg.Ctx, client.RootlessDynamic(), deploymentOpts)
 	if err != nil {
-		return clierror.Wrap(err, clierror.New("failed to create deployment"))
+		return clierror.Wrap(err, clierror.New("failed to apply deployment"))
+	}
+
+	if owner.GetUID() == "" {
+		// objects applied before the first deployment are adopted by it so they are removed with the app
+		clierr = adoptAppConfig(cfg, client, inputs, deployment, vcapServices)
+		if clierr != nil {
+			return clierr
+		}
 	}
 
 	if cfg.containerPort.Value != nil {
-		fmt.Printf("\nCreating service %s/%s\n", cfg.namespace, cfg.name)
-		err = resources.CreateService(cfg.Ctx, client, cfg.name, cfg.namespace, int32(*cfg.containerPort.Value))
+		fmt.Printf("\nApplying service %s/%s\n", cfg.namespace, cfg.name)
+		err = resources.ApplyService(cfg.Ctx, client.RootlessDynamic(), cfg.name, cfg.namespace, int32(*cfg.containerPort.Value))
 		if err != nil {
-			return clierror.Wrap(err, clierror.New("failed to create service"))
+			return clierror.Wrap(err, clierror.New("failed to apply service"))
 		}
 	}
 
 	if cfg.expose {
-		fmt.Printf("\nCreating API Rule %s/%s\n", cfg.namespace, cfg.name)
-		var domain string
-		domain, clierr = client.Istio().GetClusterAddressFromGateway(cfg.Ctx)
-		if clierr != nil {
-			return clierror.WrapE(clierr, clierror.New("failed to get cluster address from gateway", "Make sure Istio module is installed"))
+		fmt.Printf("\nApplying API Rule %s/%s\n", cfg.namespace, cfg.name)
+		err = resources.ApplyAPIRule(cfg.Ctx, client.RootlessDynamic(), buildAPIRuleOpts(cfg, inputs, domain))
+		if err != nil {
+			return clierror.Wrap(err, clierror.New("failed to apply API Rule", "Make sure API Gateway module is installed", "Make sure APIRule is available in v2alpha1 version"))
+		}
+	}
+
+	if !cfg.wait {
+		return nil
+	}
+
+	fmt.Println()
+	ctx, cancel := context.WithTimeout(cfg.Ctx, cfg.timeout)
+	defer cancel()
+
+	return app.WaitForRollout(ctx, client, os.Stdout, cfg.name, cfg.namespace, 2*time.Second)
+}
+
+// getAppOwner returns the app deployment stored in the cluster
+// the deployment without UID is returned if the app is pushed for the first time
+func getAppOwner(cfg *appPushConfig, client kube.Client) (*appsv1.Deployment, clierror.Error) {
+	deployment, err := client.Static().AppsV1().Deployments(cfg.namespace).Get(cfg.Ctx, cfg.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cfg.name,
+				Namespace: cfg.namespace,
+			},
+		}, nil
+	}
+	if err != nil {
+		return nil, clierror.Wrap(err, clierror.New("failed to get deployment"))
+	}
+
+	return deployment, nil
+}
+
+// applyAppConfig applies ConfigMaps, Secrets and service bindings the app deployment depends on
+// it returns VCAP_SERVICES of bound services if the vcap format is used
+func applyAppConfig(cfg *appPushConfig, client kube.Client, inputs *appPushInputs, owner *appsv1.Deployment) (string, clierror.Error) {
+	if inputs.envFileData != nil {
+		fmt.Printf("\nApplying config map %s/%s\n", cfg.namespace, resources.EnvObjectName(cfg.name))
+		err := resources.ApplyEnvConfigMap(cfg.Ctx, client.RootlessDynamic(), owner, inputs.envFileData)
+		if err != nil {
+			return "", clierror.Wrap(err, clierror.New("failed to apply config map with environment variables"))
+		}
+	}
+
+	if inputs.secretEnvFileData != nil {
+		fmt.Printf("\nApplying secret %s/%s\n", cfg.namespace, resources.EnvObjectName(cfg.name))
+		err := resources.ApplyEnvSecret(cfg.Ctx, client.RootlessDynamic(), owner, inputs.secretEnvFileData)
+		if err != nil {
+			return "", clierror.Wrap(err, clierror.New("failed to apply secret with environment variables"))
+		}
+	}
+
+	if len(cfg.bindings) == 0 {
+		return "", nil
+	}
+
+	return bindServices(cfg, client, owner)
+}
+
+// bindServices creates service bindings of the app deployment and waits until their secrets are ready
+// credentials are copied to the VCAP_SERVICES variable if the vcap format is used
+func bindServices(cfg *appPushConfig, client kube.Client, owner *appsv1.Deployment) (string, clierror.Error) {
+	fmt.Println()
+	ctx, cancel := context.WithTimeout(cfg.Ctx, cfg.timeout)
+	defer cancel()
+
+	err := app.CreateServiceBindings(ctx, client, os.Stdout, owner, cfg.bindings, 2*time.Second)
+	if err != nil {
+		return "", clierror.Wrap(err, clierror.New("failed to bind services to the app",
+			"Make sure BTP Operator module is installed",
+			"Make sure service instances exist in the app namespace and are ready",
+			"Increase the timeout using the --timeout flag"))
+	}
+
+	if cfg.bindFormat != bindFormatVCAP {
+		return "", nil
+	}
+
+	vcapServices, err := app.BuildVCAPServices(cfg.Ctx, client, cfg.namespace, cfg.bindings)
+	if err != nil {
+		return "", clierror.Wrap(err, clierror.New("failed to build VCAP_SERVICES from service bindings"))
+	}
+
+	fmt.Printf("\nApplying secret %s/%s\n", cfg.namespace, resources.VCAPObjectName(cfg.name))
+	err = resources.ApplyVCAPSecret(cfg.Ctx, client.RootlessDynamic(), owner, vcapServices)
+	if err != nil {
+		return "", clierror.Wrap(err, clierror.New("failed to apply secret with VCAP_SERVICES"))
+	}
+
+	return vcapServices, nil
+}
+
+// adoptAppConfig applies objects the app deployment depends on again with the owner reference to the deployment
+// their data is not changed so pods are not restarted
+func adoptAppConfig(cfg *appPushConfig, client kube.Client, inputs *appPushInputs, deployment *appsv1.Deployment, vcapServices string) clierror.Error {
+	for _, obj := range buildAppConfigObjects(cfg, inputs, deployment, vcapServices) {
+		manifest, err := resources.ToUnstructured(obj)
+		if err != nil {
+			return clierror.Wrap(err, clierror.New("failed to build application manifests"))
 		}
 
-		err = resources.CreateAPIRule(cfg.Ctx, client.RootlessDynamic(), cfg.name, cfg.namespace, domain, uint32(*cfg.containerPort.Value))
+		err = client.RootlessDynamic().Apply(cfg.Ctx, manifest)
 		if err != nil {
-			return clierror.Wrap(err, clierror.New("failed to create API Rule", "Make sure API Gateway module is installed", "Make sure APIRule is available in v2alpha1 version"))
+			return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to set owner of %s %s/%s", manifest.GetKind(), manifest.GetNamespace(), manifest.GetName())))
 		}
 	}
 
 	return nil
 }
 
+// buildAppConfigObjects builds ConfigMaps, Secrets and service bindings the app deployment depends on
+func buildAppConfigObjects(cfg *appPushConfig, inputs *appPushInputs, owner *appsv1.Deployment, vcapServices string) []interface{} {
+	objs := []interface{}{}
+	if inputs.envFileData != nil {
+		objs = append(objs, resources.BuildEnvConfigMap(owner, inputs.envFileData))
+	}
+	if inputs.secretEnvFileData != nil {
+		objs = append(objs, resources.BuildEnvSecret(owner, inputs.secretEnvFileData))
+	}
+	for _, binding := range cfg.bindings {
+		objs = append(objs, app.BuildServiceBinding(owner, binding))
+	}
+	if len(cfg.bindings) > 0 && cfg.bindFormat == bindFormatVCAP {
+		objs = append(objs, resources.BuildVCAPSecret(owner, vcapServices))
+	}
+
+	return objs
+}
+
+// buildConfigChecksum returns the checksum of configuration generated for the app
+func buildConfigChecksum(cfg *appPushConfig, inputs *appPushInputs, vcapServices string) string {
+	if inputs.envFileData == nil && inputs.secretEnvFileData == nil && (len(cfg.bindings) == 0 || cfg.bindFormat != bindFormatVCAP) {
+		return ""
+	}
+
+	return resources.ConfigChecksum(inputs.envFileData, inputs.secretEnvFileData, map[string]string{"VCAP_SERVICES": vcapServices})
+}
+
+// runAppPushServerDryRun sends manifests of the app with the DryRun: All option to validate them against admission webhooks
+// validated manifests are rendered the same way as in the dry run
+func runAppPushServerDryRun(cfg *appPushConfig, client kube.Client, inputs *appPushInputs, domain string) clierror.Error {
+	manifests, clierr := buildAppManifests(cfg, inputs, cfg.image, domain)
+	if clierr != nil {
+		return clierr
+	}
+
+	dryRunClient := rootlessdynamic.NewDryRunClient(client.Dynamic(), client.Static().Discovery())
+	for _, manifest := range manifests {
+		// progress is written to stderr to keep rendered manifests valid
+		fmt.Fprintf(os.Stderr, "Validating %s %s/%s\n", manifest.GetKind(), manifest.GetNamespace(), manifest.GetName())
+		err := dryRunClient.Apply(cfg.Ctx, &manifest)
+		if err != nil {
+			return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to validate %s %s/%s", manifest.GetKind(), manifest.GetNamespace(), manifest.GetName()),
+				"Check the error details returned by the API server or admission webhooks"))
+		}
+	}
+
+	return writeAppManifests(cfg, manifests)
+}
+
+func renderAppManifests(cfg *appPushConfig, inputs *appPushInputs, image, domain string) clierror.Error {
+	manifests, clierr := buildAppManifests(cfg, inputs, image, domain)
+	if clierr != nil {
+		return clierr
+	}
+
+	return writeAppManifests(cfg, manifests)
+}
+
+// buildAppManifests builds all resources of the app applied by the push command
+// objects the deployment depends on are rendered before it in the same order as they are applied
+func buildAppManifests(cfg *appPushConfig, inputs *appPushInputs, image, domain string) ([]unstructured.Unstructured, clierror.Error) {
+	deploymentOpts := buildDeploymentOpts(cfg, inputs, image, "")
+	deploymentOpts.ConfigChecksum = buildConfigChecksum(cfg, inputs, dryRunVCAPServices)
+	deployment := resources.BuildDeployment(deploymentOpts)
+
+	// credentials are known only after binding so the VCAP_SERVICES secret is rendered without services
+	objs := buildAppConfigObjects(cfg, inputs, deployment, dryRunVCAPServices)
+	objs = append(objs, deployment)
+	if cfg.containerPort.Value != nil {
+		objs = append(objs, resources.BuildService(cfg.name, cfg.namespace, int32(*cfg.containerPort.Value)))
+	}
+	if cfg.expose {
+		objs = append(objs, resources.BuildAPIRule(buildAPIRuleOpts(cfg, inputs, domain)))
+	}
+
+	manifests := []unstructured.Unstructured{}
+	for _, obj := range objs {
+		manifest, err := resources.ToUnstructured(obj)
+		if err != nil {
+			return nil, clierror.Wrap(err, clierror.New("failed to build application manifests"))
+		}
+		manifests = append(manifests, *manifest)
+	}
+
+	return manifests, nil
+}
+
+func writeAppManifests(cfg *appPushConfig, manifests []unstructured.Unstructured) clierror.Error {
+	if cfg.outputDir != "" {
+		err := app.SaveManifests(cfg.outputDir, manifests, cfg.outputFormat)
+		if err != nil {
+			return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to save application manifests to the %s directory", cfg.outputDir)))
+		}
+		return nil
+	}
+
+	err := app.RenderManifests(os.Stdout, manifests, cfg.outputFormat)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to render application manifests"))
+	}
+	return nil
+}
+
+func readAppPushInputs(cfg *appPushConfig) (*appPushInputs, clierror.Error) {
+	inputs := &appPushInputs{}
+	var clierr clierror.Error
+	if cfg.envFile != "" {
+		inputs.envFileData, clierr = readEnvFile(cfg.envFile)
+		if clierr != nil {
+			return nil, clierr
+		}
+	}
+
+	if cfg.secretEnvFile != "" {
+		inputs.secretEnvFileData, clierr = readEnvFile(cfg.secretEnvFile)
+		if clierr != nil {
+			return nil, clierr
+		}
+	}
+
+	if cfg.expose {
+		inputs.apiRuleRules, clierr = buildAPIRuleRules(cfg)
+		if clierr != nil {
+			return nil, clierr
+		}
+	}
+
+	return inputs, nil
+}
+
+func buildDeploymentOpts(cfg *appPushConfig, inputs *appPushInputs, image, imagePullSecret string) resources.DeploymentOpts {
+	// slices are cloned so generated names are not appended to the backing arrays of flag values
+	envFromConfigmaps := slices.Clone(cfg.envFromConfigmaps)
+	if inputs.envFileData != nil {
+		envFromConfigmaps = append(envFromConfigmaps, resources.EnvObjectName(cfg.name))
+	}
+
+	envFromSecrets := slices.Clone(cfg.envFromSecrets)
+	if inputs.secretEnvFileData != nil {
+		envFromSecrets = append(envFromSecrets, resources.EnvObjectName(cfg.name))
+	}
+
+	env := cfg.env
+	secretMounts := slices.Clone(cfg.secretMounts)
+	if len(cfg.bindings) > 0 && cfg.bindFormat == bindFormatVCAP {
+		envFromSecrets = append(envFromSecrets, resources.VCAPObjectName(cfg.name))
+	}
+	if len(cfg.bindings) > 0 && cfg.bindFormat == bindFormatFiles {
+		secretMounts = append(secretMounts, app.BindingMounts(cfg.bindings)...)
+		// the variable set by the user takes precedence
+		env = map[string]string{"SERVICE_BINDING_ROOT": app.BindingsRoot}
+		maps.Copy(env, cfg.env)
+	}
+
+	return resources.DeploymentOpts{
+		Name:              cfg.name,
+		Namespace:         cfg.namespace,
+		Image:             image,
+		ImagePullSecret:   imagePullSecret,
+		InjectIstio:       cfg.istioInject,
+		Replicas:          cfg.replicas,
+		ContainerPort:     cfg.containerPort,
+		Resources:         cfg.resources,
+		LivenessProbe:     cfg.livenessProbe,
+		ReadinessProbe:    cfg.readinessProbe,
+		Command:           cfg.command,
+		Args:              cfg.args,
+		Env:               env,
+		EnvFromConfigMaps: envFromConfigmaps,
+		EnvFromSecrets:    envFromSecrets,
+		ConfigMapMounts:   cfg.configMapMounts,
+		SecretMounts:      secretMounts,
+	}
+}
+
+func buildAPIRuleOpts(cfg *appPushConfig, inputs *appPushInputs, domain string) resources.APIRuleOpts {
+	return resources.APIRuleOpts{
+		Name:      cfg.name,
+		Namespace: cfg.namespace,
+		Domain:    domain,
+		Port:      uint32(*cfg.containerPort.Value),
+		Rules:     inputs.apiRuleRules,
+	}
+}
+
+func readEnvFile(path string) (map[string]string, clierror.Error) {
+	data, err := resources.ReadEnvFile(path)
+	if err != nil {
+		return nil, clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to read env file %s", path),
+			"Make sure the file exists and contains lines in the format 'KEY=VALUE'"))
+	}
+
+	return data, nil
+}
+
+func buildAPIRuleRules(cfg *appPushConfig) ([]v2alpha1.Rule, clierror.Error) {
+	if cfg.exposeRulesFile == "" {
+		return resources.BuildDefaultAPIRuleRules(resources.APIRuleAuth{
+			JwtIssuer:         cfg.jwtIssuer,
+			JwtJwksURI:        cfg.jwtJwksURI,
+			JwtRequiredScopes: cfg.jwtRequiredScopes,
+			ExtAuthorizers:    cfg.extAuthorizers,
+		}), nil
+	}
+
+	rules, err := resources.ReadAPIRuleRulesFile(cfg.exposeRulesFile)
+	if err != nil {
+		return nil, clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to read APIRule rules file %s", cfg.exposeRulesFile),
+			"Make sure the file contains the 'rules' list in the v2alpha1 APIRule format",
+			"Make sure every rule has path, methods and exactly one of noAuth, jwt or extAuth"))
+	}
+
+	return rules, nil
+}
+
 func buildAndImportImage(client kube.Client, cfg *appPushConfig, registryConfig *registry.InternalRegistryConfig) (string, clierror.Error) {
 	fmt.Println("Building image")
 	imageName, err := buildImage(cfg)
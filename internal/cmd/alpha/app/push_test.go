@@ -0,0 +1,80 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-project/cli.v3/internal/app"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_appPushConfig_loadDescriptor(t *testing.T) {
+	t.Run("set flags from descriptor", func(t *testing.T) {
+		config, flags := fixDescriptorFlags(t, `name: app
+dockerfile: Dockerfile
+dockerfileContext: src
+`)
+
+		require.NoError(t, flags.Parse([]string{}))
+		require.Nil(t, config.loadDescriptor(flags))
+		require.Equal(t, "app", config.name)
+		// paths are relative to the descriptor file
+		require.Equal(t, filepath.Join(filepath.Dir(config.descriptorFile), "Dockerfile"), config.dockerfilePath)
+		require.Equal(t, filepath.Join(filepath.Dir(config.descriptorFile), "src"), config.dockerfileSrcContext)
+	})
+
+	t.Run("flag overrides descriptor group", func(t *testing.T) {
+		config, flags := fixDescriptorFlags(t, `name: app
+dockerfile: Dockerfile
+dockerfileContext: src
+expose:
+  rulesFile: rules.yaml
+`)
+
+		require.NoError(t, flags.Parse([]string{"--image", "app:1.0.0", "--jwt-issuer", "https://issuer.example.com"}))
+		require.Nil(t, config.loadDescriptor(flags))
+		require.Equal(t, "app", config.name)
+		require.Equal(t, "app:1.0.0", config.image)
+		require.Empty(t, config.dockerfilePath)
+		require.Empty(t, config.dockerfileSrcContext)
+		require.Equal(t, "https://issuer.example.com", config.jwtIssuer)
+		require.Empty(t, config.exposeRulesFile)
+	})
+}
+
+// fixDescriptorFlags returns config with flags set from the descriptor file
+func fixDescriptorFlags(t *testing.T, descriptor string) (*appPushConfig, *pflag.FlagSet) {
+	path := filepath.Join(t.TempDir(), "kyma-app.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(descriptor), 0600))
+
+	config := &appPushConfig{descriptorFile: path}
+	flags := pflag.NewFlagSet("push", pflag.ContinueOnError)
+	flags.StringVar(&config.name, "name", "", "")
+	flags.StringVar(&config.image, "image", "", "")
+	flags.StringVar(&config.dockerfilePath, "dockerfile", "", "")
+	flags.StringVar(&config.dockerfileSrcContext, "dockerfile-context", "", "")
+	flags.StringVar(&config.jwtIssuer, "jwt-issuer", "", "")
+	flags.StringVar(&config.exposeRulesFile, "expose-rules-file", "", "")
+	return config, flags
+}
+
+func Test_buildDeploymentOpts(t *testing.T) {
+	t.Run("don't modify flag values", func(t *testing.T) {
+		// flag values with spare capacity share the backing array with appended values
+		envFromSecrets := make([]string, 1, 4)
+		envFromSecrets[0] = "user-secret"
+		config := &appPushConfig{
+			name:           "app",
+			envFromSecrets: envFromSecrets,
+			bindings:       []app.Binding{{Instance: "instance", Name: "app-instance"}},
+			bindFormat:     bindFormatVCAP,
+		}
+
+		opts := buildDeploymentOpts(config, &appPushInputs{secretEnvFileData: map[string]string{"KEY": "value"}}, "app:1.0.0", "")
+		require.Equal(t, []string{"user-secret", "app-env", "app-vcap"}, opts.EnvFromSecrets)
+		require.Equal(t, []string{"user-secret"}, config.envFromSecrets)
+		require.Equal(t, "", envFromSecrets[:2][1])
+	})
+}
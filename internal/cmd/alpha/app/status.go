@@ -0,0 +1,73 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kyma-project/cli.v3/internal/app"
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type appStatusConfig struct {
+	*cmdcommon.KymaConfig
+
+	name         string
+	namespace    string
+	outputFormat types.Format
+}
+
+func NewAppStatusCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
+	config := appStatusConfig{
+		KymaConfig: kymaConfig,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "status <name>",
+		Short: "Show status of the application.",
+		Long:  "Use this command to show the rollout state of the application with its pods, their restarts and recent events.",
+		Args:  cobra.ExactArgs(1),
+
+		PreRun: func(_ *cobra.Command, args []string) {
+			config.complete(args)
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			clierror.Check(runAppStatus(&config))
+		},
+	}
+
+	cmd.Flags().StringVar(&config.namespace, "namespace", "default", "Namespace of the application")
+	cmd.Flags().VarP(&config.outputFormat, "output", "o", "Output format (possible values: json, yaml)")
+
+	return cmd
+}
+
+func (asc *appStatusConfig) complete(args []string) {
+	asc.name = args[0]
+}
+
+func runAppStatus(cfg *appStatusConfig) clierror.Error {
+	client, clierr := cfg.GetKubeClientWithClierr()
+	if clierr != nil {
+		return clierr
+	}
+
+	status, err := app.GetStatus(cfg.Ctx, client, cfg.name, cfg.namespace)
+	if apierrors.IsNotFound(err) {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("application %s/%s not found", cfg.namespace, cfg.name),
+			"Use the 'kyma alpha app list' command to list applications in the namespace"))
+	}
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to get application status"))
+	}
+
+	err = app.RenderStatus(os.Stdout, status, cfg.outputFormat)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to render application status"))
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/kyma-project/cli.v3/internal/modules"
+	"github.com/spf13/cobra"
+)
+
+type addConfig struct {
+	*cmdcommon.KymaConfig
+
+	module    string
+	channel   string
+	defaultCR bool
+	wait      bool
+	timeout   time.Duration
+	community bool
+	version   string
+}
+
+func NewAddCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
+	cfg := addConfig{
+		KymaConfig: kymaConfig,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "add <module>",
+		Short: "Add a module.",
+		Long: `Add module to the default Kyma CR and let the lifecycle-manager install it.
+Community modules are installed directly by applying resources linked in their ModuleTemplate.`,
+		Args: cobra.ExactArgs(1),
+		PreRun: func(_ *cobra.Command, args []string) {
+			cfg.complete(args)
+			clierror.Check(cfg.validate())
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			clierror.Check(runAdd(&cfg))
+		},
+	}
+
+	cmd.Flags().StringVar(&cfg.channel, "channel", "", "Name of the Kyma channel to use for the module. The default channel from the Kyma CR is used if empty.")
+	cmd.Flags().BoolVar(&cfg.defaultCR, "default-cr", false, "Deploy the module with the default CR.")
+	cmd.Flags().BoolVar(&cfg.wait, "wait", false, "Wait until the module is in the Ready state.")
+	cmd.Flags().DurationVar(&cfg.timeout, "timeout", 5*time.Minute, "Maximum time to wait for the module to be ready.")
+	cmd.Flags().BoolVar(&cfg.community, "community", false, "Install the community module by applying resources from its ModuleTemplate.")
+	cmd.Flags().StringVar(&cfg.version, "version", "", "Version of the community module to install. Required if more than one version is available.")
+
+	cmd.MarkFlagsMutuallyExclusive("community", "channel")
+	cmd.MarkFlagsMutuallyExclusive("community", "wait")
+
+	return cmd
+}
+
+func (ac *addConfig) complete(args []string) {
+	ac.module = args[0]
+}
+
+func (ac *addConfig) validate() clierror.Error {
+	if ac.version != "" && !ac.community {
+		return clierror.New("version can be used only with the community flag")
+	}
+	return nil
+}
+
+func runAdd(cfg *addConfig) clierror.Error {
+	client, clierr := cfg.GetKubeClientWithClierr()
+	if clierr != nil {
+		return clierr
+	}
+
+	if cfg.community {
+		clierr = modules.EnableCommunity(cfg.Ctx, client, cfg.module, cfg.version, cfg.defaultCR)
+		if clierr != nil {
+			return clierr
+		}
+
+		fmt.Printf("Community module %s installed\n", cfg.module)
+		return nil
+	}
+
+	clierr = modules.Enable(cfg.Ctx, client.Kyma(), cfg.module, cfg.channel, cfg.defaultCR)
+	if clierr != nil {
+		return clierr
+	}
+
+	fmt.Printf("Module %s added to the default Kyma CR\n", cfg.module)
+
+	if !cfg.wait {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(cfg.Ctx, cfg.timeout)
+	defer cancel()
+
+	return modules.WaitForModuleState(ctx, client.Kyma(), os.Stdout, cfg.module, 2*time.Second)
+}
@@ -0,0 +1,85 @@
+package modules
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/kyma-project/cli.v3/internal/modules"
+	"github.com/spf13/cobra"
+)
+
+type applyConfig struct {
+	*cmdcommon.KymaConfig
+
+	file   string
+	dryRun bool
+	force  bool
+}
+
+func NewApplyCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
+	cfg := applyConfig{
+		KymaConfig: kymaConfig,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Apply a set of modules.",
+		Long: `Apply the desired set of modules from the file to the default Kyma CR.
+Modules missing in the file are removed from the Kyma CR, new ones are added and existing ones are updated.
+
+Example file:
+  channel: regular
+  modules:
+    - name: serverless
+      channel: fast
+      customResourcePolicy: CreateAndDelete
+      managed: true
+      cr:
+        spec:
+          enableNetworkPolicies: true
+    - name: keda`,
+		Run: func(_ *cobra.Command, _ []string) {
+			clierror.Check(runApply(&cfg))
+		},
+	}
+
+	cmd.Flags().StringVarP(&cfg.file, "file", "f", "", "Path to the file with the set of modules.")
+	cmd.Flags().BoolVar(&cfg.dryRun, "dry-run", false, "Print the plan without applying it.")
+	cmd.Flags().BoolVar(&cfg.force, "force", false, "Remove modules even if there are resources blocking their deletion.")
+
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runApply(cfg *applyConfig) clierror.Error {
+	moduleSet, clierr := modules.ReadModuleSet(cfg.file)
+	if clierr != nil {
+		return clierr
+	}
+
+	client, clierr := cfg.GetKubeClientWithClierr()
+	if clierr != nil {
+		return clierr
+	}
+
+	plan, clierr := modules.PlanModuleSet(cfg.Ctx, client, moduleSet)
+	if clierr != nil {
+		return clierr
+	}
+
+	modules.RenderModuleSetPlan(os.Stdout, plan)
+	if cfg.dryRun || plan.IsEmpty() {
+		return nil
+	}
+
+	clierr = modules.ApplyModuleSet(cfg.Ctx, client, plan, cfg.force)
+	if clierr != nil {
+		return clierr
+	}
+
+	fmt.Println("Modules applied")
+	return nil
+}
@@ -0,0 +1,20 @@
+package modules
+
+import (
+	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/spf13/cobra"
+)
+
+func NewConfigCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage module configuration.",
+		Long:  `Use this command to get, edit or apply the module CR that configures the module.`,
+	}
+
+	cmd.AddCommand(NewConfigGetCMD(kymaConfig))
+	cmd.AddCommand(NewConfigEditCMD(kymaConfig))
+	cmd.AddCommand(NewConfigApplyCMD(kymaConfig))
+
+	return cmd
+}
@@ -0,0 +1,84 @@
+package modules
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/kyma-project/cli.v3/internal/kube/resources"
+	"github.com/kyma-project/cli.v3/internal/modules"
+	"github.com/spf13/cobra"
+)
+
+type configApplyConfig struct {
+	*cmdcommon.KymaConfig
+
+	module string
+	file   string
+	reset  bool
+}
+
+func NewConfigApplyCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
+	cfg := configApplyConfig{
+		KymaConfig: kymaConfig,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "apply <module>",
+		Short: "Apply the module CR.",
+		Long: `Apply spec of the module CR from the file using server-side apply.
+Use the --reset flag to restore spec of the default CR from the module's ModuleTemplate.`,
+		Args: cobra.ExactArgs(1),
+		PreRun: func(_ *cobra.Command, args []string) {
+			cfg.complete(args)
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			clierror.Check(runConfigApply(&cfg))
+		},
+	}
+
+	cmd.Flags().StringVarP(&cfg.file, "file", "f", "", "Path to the file with the module CR.")
+	cmd.Flags().BoolVar(&cfg.reset, "reset", false, "Reset the module CR to the default from the ModuleTemplate.")
+
+	cmd.MarkFlagsMutuallyExclusive("file", "reset")
+	cmd.MarkFlagsOneRequired("file", "reset")
+
+	return cmd
+}
+
+func (cac *configApplyConfig) complete(args []string) {
+	cac.module = args[0]
+}
+
+func runConfigApply(cfg *configApplyConfig) clierror.Error {
+	client, clierr := cfg.GetKubeClientWithClierr()
+	if clierr != nil {
+		return clierr
+	}
+
+	if cfg.reset {
+		clierr = modules.ResetConfig(cfg.Ctx, client, cfg.module)
+		if clierr != nil {
+			return clierr
+		}
+
+		fmt.Printf("Configuration of the %s module reset to default\n", cfg.module)
+		return nil
+	}
+
+	objs, err := resources.ReadFromFiles(cfg.file)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to read the module CR from file"))
+	}
+	if len(objs) != 1 {
+		return clierror.New(fmt.Sprintf("expected one resource in the %s file, found %d", cfg.file, len(objs)))
+	}
+
+	clierr = modules.ApplyConfig(cfg.Ctx, client, cfg.module, &objs[0])
+	if clierr != nil {
+		return clierr
+	}
+
+	fmt.Printf("Configuration of the %s module applied\n", cfg.module)
+	return nil
+}
@@ -0,0 +1,131 @@
+package modules
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/kyma-project/cli.v3/internal/kube/resources"
+	"github.com/kyma-project/cli.v3/internal/modules"
+	"github.com/spf13/cobra"
+)
+
+const defaultEditor = "vi"
+
+type configEditConfig struct {
+	*cmdcommon.KymaConfig
+
+	module string
+}
+
+func NewConfigEditCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
+	cfg := configEditConfig{
+		KymaConfig: kymaConfig,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "edit <module>",
+		Short: "Edit the module CR.",
+		Long: `Edit the module CR in the editor defined by the EDITOR environment variable and apply the changed spec using server-side apply.
+The vi editor is used if the EDITOR variable is not set.`,
+		Args: cobra.ExactArgs(1),
+		PreRun: func(_ *cobra.Command, args []string) {
+			cfg.complete(args)
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			clierror.Check(runConfigEdit(&cfg))
+		},
+	}
+
+	return cmd
+}
+
+func (cec *configEditConfig) complete(args []string) {
+	cec.module = args[0]
+}
+
+func runConfigEdit(cfg *configEditConfig) clierror.Error {
+	client, clierr := cfg.GetKubeClientWithClierr()
+	if clierr != nil {
+		return clierr
+	}
+
+	moduleCR, clierr := modules.GetConfig(cfg.Ctx, client, cfg.module)
+	if clierr != nil {
+		return clierr
+	}
+
+	original := bytes.NewBuffer([]byte{})
+	err := modules.RenderConfig(original, moduleCR, types.YAMLFormat)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to render module CR"))
+	}
+
+	edited, clierr := editInEditor(original.Bytes())
+	if clierr != nil {
+		return clierr
+	}
+
+	if bytes.Equal(original.Bytes(), edited) {
+		fmt.Println("Edit cancelled, no changes made")
+		return nil
+	}
+
+	objs, err := resources.DecodeYaml(bytes.NewReader(edited))
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to decode the edited module CR", "Make sure the edited file is a valid yaml"))
+	}
+	if len(objs) != 1 {
+		return clierror.New(fmt.Sprintf("expected one resource after edit, found %d", len(objs)))
+	}
+
+	clierr = modules.ApplyConfig(cfg.Ctx, client, cfg.module, &objs[0])
+	if clierr != nil {
+		return clierr
+	}
+
+	fmt.Printf("Configuration of the %s module applied\n", cfg.module)
+	return nil
+}
+
+// editInEditor opens the given content in the user's editor and returns the content after edit
+func editInEditor(content []byte) ([]byte, clierror.Error) {
+	file, err := os.CreateTemp("", "kyma-module-config-*.yaml")
+	if err != nil {
+		return nil, clierror.Wrap(err, clierror.New("failed to create temporary file"))
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(content)
+	file.Close()
+	if err != nil {
+		return nil, clierror.Wrap(err, clierror.New("failed to write temporary file"))
+	}
+
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{defaultEditor}
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], file.Name())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err = cmd.Run()
+	if err != nil {
+		return nil, clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to run the %s editor", editor[0]),
+			"Set the EDITOR environment variable to the editor you want to use"))
+	}
+
+	edited, err := os.ReadFile(file.Name())
+	if err != nil {
+		return nil, clierror.Wrap(err, clierror.New("failed to read temporary file"))
+	}
+
+	return edited, nil
+}
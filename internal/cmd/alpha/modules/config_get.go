@@ -0,0 +1,64 @@
+package modules
+
+import (
+	"os"
+
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/kyma-project/cli.v3/internal/modules"
+	"github.com/spf13/cobra"
+)
+
+type configGetConfig struct {
+	*cmdcommon.KymaConfig
+
+	module       string
+	outputFormat types.Format
+}
+
+func NewConfigGetCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
+	cfg := configGetConfig{
+		KymaConfig: kymaConfig,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "get <module>",
+		Short: "Get the module CR.",
+		Long:  `Get the module CR from the cluster.`,
+		Args:  cobra.ExactArgs(1),
+		PreRun: func(_ *cobra.Command, args []string) {
+			cfg.complete(args)
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			clierror.Check(runConfigGet(&cfg))
+		},
+	}
+
+	cmd.Flags().VarP(&cfg.outputFormat, "output", "o", "Output format (possible values: json, yaml).")
+
+	return cmd
+}
+
+func (cgc *configGetConfig) complete(args []string) {
+	cgc.module = args[0]
+}
+
+func runConfigGet(cfg *configGetConfig) clierror.Error {
+	client, clierr := cfg.GetKubeClientWithClierr()
+	if clierr != nil {
+		return clierr
+	}
+
+	moduleCR, clierr := modules.GetConfig(cfg.Ctx, client, cfg.module)
+	if clierr != nil {
+		return clierr
+	}
+
+	err := modules.RenderConfig(os.Stdout, moduleCR, cfg.outputFormat)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to render module CR"))
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package modules
+
+import (
+	"fmt"
+
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/kyma-project/cli.v3/internal/modules"
+	"github.com/spf13/cobra"
+)
+
+type deleteConfig struct {
+	*cmdcommon.KymaConfig
+
+	module    string
+	force     bool
+	community bool
+	version   string
+}
+
+func NewDeleteCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
+	cfg := deleteConfig{
+		KymaConfig: kymaConfig,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "delete <module>",
+		Short: "Delete a module.",
+		Long: `Remove module from the default Kyma CR and let the lifecycle-manager uninstall it.
+Community modules are uninstalled directly by removing resources linked in their ModuleTemplate.
+The command fails if there are still resources associated with the module in the cluster, because they block the module deprovisioning.`,
+		Args: cobra.ExactArgs(1),
+		PreRun: func(_ *cobra.Command, args []string) {
+			cfg.complete(args)
+			clierror.Check(cfg.validate())
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			clierror.Check(runDelete(&cfg))
+		},
+	}
+
+	cmd.Flags().BoolVar(&cfg.force, "force", false, "Delete the module even if there are resources blocking its deletion.")
+	cmd.Flags().BoolVar(&cfg.community, "community", false, "Uninstall the community module by removing resources from its ModuleTemplate.")
+	cmd.Flags().StringVar(&cfg.version, "version", "", "Version of the community module to uninstall. Required if more than one version is available.")
+
+	return cmd
+}
+
+func (dc *deleteConfig) complete(args []string) {
+	dc.module = args[0]
+}
+
+func (dc *deleteConfig) validate() clierror.Error {
+	if dc.version != "" && !dc.community {
+		return clierror.New("version can be used only with the community flag")
+	}
+	return nil
+}
+
+func runDelete(cfg *deleteConfig) clierror.Error {
+	client, clierr := cfg.GetKubeClientWithClierr()
+	if clierr != nil {
+		return clierr
+	}
+
+	if cfg.community {
+		clierr = modules.DisableCommunity(cfg.Ctx, client, cfg.module, cfg.version, cfg.force)
+		if clierr != nil {
+			return clierr
+		}
+
+		fmt.Printf("Community module %s uninstalled\n", cfg.module)
+		return nil
+	}
+
+	clierr = modules.Disable(cfg.Ctx, client, cfg.module, cfg.force)
+	if clierr != nil {
+		return clierr
+	}
+
+	fmt.Printf("Module %s removed from the default Kyma CR\n", cfg.module)
+	return nil
+}
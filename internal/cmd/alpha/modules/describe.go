@@ -0,0 +1,63 @@
+package modules
+
+import (
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/kyma-project/cli.v3/internal/modules"
+	"github.com/spf13/cobra"
+)
+
+type describeConfig struct {
+	*cmdcommon.KymaConfig
+
+	module       string
+	outputFormat types.Format
+}
+
+func NewDescribeCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
+	cfg := describeConfig{
+		KymaConfig: kymaConfig,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "describe <module>",
+		Short: "Describe a module.",
+		Long:  `Describe all available versions of the module with metadata from their ModuleTemplates.`,
+		Args:  cobra.ExactArgs(1),
+		PreRun: func(_ *cobra.Command, args []string) {
+			cfg.complete(args)
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			clierror.Check(runDescribe(&cfg))
+		},
+	}
+
+	cmd.Flags().VarP(&cfg.outputFormat, "output", "o", "Output format (possible values: table, json, yaml).")
+
+	return cmd
+}
+
+func (dc *describeConfig) complete(args []string) {
+	dc.module = args[0]
+}
+
+func runDescribe(cfg *describeConfig) clierror.Error {
+	client, clierr := cfg.GetKubeClientWithClierr()
+	if clierr != nil {
+		return clierr
+	}
+
+	description, err := modules.Describe(cfg.Ctx, client, cfg.module)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to describe module",
+			"Make sure the module is available on the cluster"))
+	}
+
+	err = modules.RenderDescription(description, cfg.outputFormat)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to render module description"))
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package modules
+
+import (
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/kyma-project/cli.v3/internal/modules"
+	"github.com/spf13/cobra"
+)
+
+type imagesConfig struct {
+	*cmdcommon.KymaConfig
+
+	module       string
+	version      string
+	outputFormat types.Format
+}
+
+func NewImagesCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
+	cfg := imagesConfig{
+		KymaConfig: kymaConfig,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "images <module>",
+		Short: "List images of a module.",
+		Long:  `List container images, their digests and source repositories from the component descriptor of the module version.`,
+		Args:  cobra.ExactArgs(1),
+		PreRun: func(_ *cobra.Command, args []string) {
+			cfg.complete(args)
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			clierror.Check(runImages(&cfg))
+		},
+	}
+
+	cmd.Flags().StringVar(&cfg.version, "version", "", "Version of the module. The installed version is used if empty.")
+	cmd.Flags().VarP(&cfg.outputFormat, "output", "o", "Output format (possible values: table, json, yaml).")
+
+	return cmd
+}
+
+func (ic *imagesConfig) complete(args []string) {
+	ic.module = args[0]
+}
+
+func runImages(cfg *imagesConfig) clierror.Error {
+	client, clierr := cfg.GetKubeClientWithClierr()
+	if clierr != nil {
+		return clierr
+	}
+
+	images, clierr := modules.ListImages(cfg.Ctx, client.Kyma(), cfg.module, cfg.version)
+	if clierr != nil {
+		return clierr
+	}
+
+	err := modules.RenderImages(images, cfg.outputFormat)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to render module images"))
+	}
+
+	return nil
+}
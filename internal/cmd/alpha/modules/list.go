@@ -1,14 +1,24 @@
 package modules
 
 import (
+	"context"
+	"errors"
+
 	"github.com/kyma-project/cli.v3/internal/clierror"
 	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/kyma-project/cli.v3/internal/kube"
 	"github.com/kyma-project/cli.v3/internal/modules"
 	"github.com/spf13/cobra"
 )
 
 type modulesConfig struct {
 	*cmdcommon.KymaConfig
+
+	outputFormat types.Format
+	watch        bool
+	channel      string
+	modules      []string
 }
 
 func NewListCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
@@ -25,6 +35,11 @@ func NewListCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().VarP(&cfg.outputFormat, "output", "o", "Output format (possible values: table, json, yaml).")
+	cmd.Flags().BoolVarP(&cfg.watch, "watch", "w", false, "Watch for changes of modules and print the list every time it changes. States of unmanaged modules are refreshed every 10 seconds.")
+	cmd.Flags().StringVar(&cfg.channel, "channel", "", "List only module versions assigned to the channel.")
+	cmd.Flags().StringSliceVar(&cfg.modules, "module", []string{}, "List only modules with the given names.")
+
 	return cmd
 }
 
@@ -35,11 +50,37 @@ func listModules(cfg *modulesConfig) clierror.Error {
 		return clierr
 	}
 
-	modulesList, err := modules.List(cfg.Ctx, client.Kyma())
+	if cfg.watch {
+		return watchModules(cfg, client)
+	}
+
+	modulesList, err := modules.List(cfg.Ctx, client, cfg.listOptions())
 	if err != nil {
 		return clierror.Wrap(err, clierror.New("failed to list available modules from the cluster"))
 	}
 
-	modules.Render(modulesList, modules.ModulesTableInfo)
+	err = modules.Render(modulesList, modules.ModulesTableInfo, cfg.outputFormat)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to render modules list"))
+	}
+
 	return nil
 }
+
+func watchModules(cfg *modulesConfig, client kube.Client) clierror.Error {
+	err := modules.Watch(cfg.Ctx, client, cfg.listOptions(), func(modulesList modules.ModulesList) error {
+		return modules.RenderWatch(modulesList, modules.ModulesTableInfo, cfg.outputFormat)
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return clierror.Wrap(err, clierror.New("failed to watch modules on the cluster"))
+	}
+
+	return nil
+}
+
+func (mc *modulesConfig) listOptions() modules.ListOptions {
+	return modules.ListOptions{
+		Channel:     mc.channel,
+		ModuleNames: mc.modules,
+	}
+}
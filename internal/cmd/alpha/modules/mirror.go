@@ -0,0 +1,80 @@
+package modules
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/kyma-project/cli.v3/internal/modules"
+	"github.com/spf13/cobra"
+)
+
+type mirrorConfig struct {
+	*cmdcommon.KymaConfig
+
+	registry  string
+	modules   []string
+	outputDir string
+	insecure  bool
+}
+
+func NewMirrorCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
+	cfg := mirrorConfig{
+		KymaConfig: kymaConfig,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "mirror",
+		Short: "Mirror modules to a private registry.",
+		Long: `Copy images, component descriptors and resources of modules to the private registry and write ModuleTemplates that reference the mirrored ones.
+Resources are stored as single layer artifacts and referenced by links to their blobs in the registry.
+ModuleTemplates without a component descriptor are skipped.
+Credentials for registries are taken from the docker config file.
+ModuleTemplates are printed to the stdout if the output directory is not set.`,
+		Run: func(_ *cobra.Command, _ []string) {
+			clierror.Check(runMirror(&cfg))
+		},
+	}
+
+	cmd.Flags().StringVar(&cfg.registry, "to", "", "Address of the target registry, for example 'registry.local:5000/kyma'.")
+	cmd.Flags().StringSliceVar(&cfg.modules, "module", []string{}, "Name of the module to mirror. All modules are mirrored if empty.")
+	cmd.Flags().StringVar(&cfg.outputDir, "output-dir", "", "Path to the directory for rewritten ModuleTemplates.")
+	cmd.Flags().BoolVar(&cfg.insecure, "insecure", false, "Allow connecting to the source and target registries over http.")
+
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func runMirror(cfg *mirrorConfig) clierror.Error {
+	client, clierr := cfg.GetKubeClientWithClierr()
+	if clierr != nil {
+		return clierr
+	}
+
+	moduleTemplates, clierr := modules.Mirror(cfg.Ctx, client.Kyma(), os.Stderr, modules.MirrorOptions{
+		Registry: cfg.registry,
+		Modules:  cfg.modules,
+		Insecure: cfg.insecure,
+	})
+	if clierr != nil {
+		return clierr
+	}
+
+	if cfg.outputDir == "" {
+		err := modules.RenderModuleTemplates(os.Stdout, moduleTemplates)
+		if err != nil {
+			return clierror.Wrap(err, clierror.New("failed to render ModuleTemplates"))
+		}
+		return nil
+	}
+
+	err := modules.SaveModuleTemplates(cfg.outputDir, moduleTemplates)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to save ModuleTemplates", "Make sure the output directory is writable"))
+	}
+
+	fmt.Fprintf(os.Stderr, "ModuleTemplates saved to %s\n", cfg.outputDir)
+	return nil
+}
@@ -13,6 +13,14 @@ func NewModulesCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
 	}
 
 	cmd.AddCommand(NewListCMD(kymaConfig))
+	cmd.AddCommand(NewAddCMD(kymaConfig))
+	cmd.AddCommand(NewDeleteCMD(kymaConfig))
+	cmd.AddCommand(NewDescribeCMD(kymaConfig))
+	cmd.AddCommand(NewConfigCMD(kymaConfig))
+	cmd.AddCommand(NewApplyCMD(kymaConfig))
+	cmd.AddCommand(NewUpgradeCMD(kymaConfig))
+	cmd.AddCommand(NewImagesCMD(kymaConfig))
+	cmd.AddCommand(NewMirrorCMD(kymaConfig))
 
 	return cmd
 }
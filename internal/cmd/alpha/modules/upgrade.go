@@ -0,0 +1,118 @@
+package modules
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon"
+	"github.com/kyma-project/cli.v3/internal/modules"
+	"github.com/spf13/cobra"
+)
+
+type upgradeConfig struct {
+	*cmdcommon.KymaConfig
+
+	module         string
+	channel        string
+	all            bool
+	allowDowngrade bool
+	dryRun         bool
+}
+
+func NewUpgradeCMD(kymaConfig *cmdcommon.KymaConfig) *cobra.Command {
+	cfg := upgradeConfig{
+		KymaConfig: kymaConfig,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "upgrade [<module>]",
+		Short: "Switch a module to another channel.",
+		Long: `Switch the module to another channel and print the version it moves to from its installed version.
+Use the --all flag to change the default channel of the Kyma CR for all modules without their own channel.
+Modules without the target channel are skipped and stay in the current default channel.`,
+		Args: cobra.MaximumNArgs(1),
+		PreRun: func(_ *cobra.Command, args []string) {
+			cfg.complete(args)
+			clierror.Check(cfg.validate())
+		},
+		Run: func(_ *cobra.Command, _ []string) {
+			clierror.Check(runUpgrade(&cfg))
+		},
+	}
+
+	cmd.Flags().StringVar(&cfg.channel, "channel", "", "Name of the channel to switch to.")
+	cmd.Flags().BoolVar(&cfg.all, "all", false, "Change the default channel of the Kyma CR.")
+	cmd.Flags().BoolVar(&cfg.allowDowngrade, "allow-downgrade", false, "Allow switching to a channel with lower module versions.")
+	cmd.Flags().BoolVar(&cfg.dryRun, "dry-run", false, "Print the upgrade preview without applying it.")
+
+	_ = cmd.MarkFlagRequired("channel")
+
+	return cmd
+}
+
+func (uc *upgradeConfig) complete(args []string) {
+	if len(args) > 0 {
+		uc.module = args[0]
+	}
+}
+
+func (uc *upgradeConfig) validate() clierror.Error {
+	if uc.all && uc.module != "" {
+		return clierror.New("module name can't be used with the all flag")
+	}
+	if !uc.all && uc.module == "" {
+		return clierror.New("module name is required", "Use the --all flag to change the default channel of the Kyma CR")
+	}
+	return nil
+}
+
+func runUpgrade(cfg *upgradeConfig) clierror.Error {
+	client, clierr := cfg.GetKubeClientWithClierr()
+	if clierr != nil {
+		return clierr
+	}
+
+	upgrades := []modules.ChannelUpgrade{}
+	if cfg.all {
+		upgrades, clierr = modules.PlanChannelUpgrade(cfg.Ctx, client.Kyma(), cfg.channel)
+	} else {
+		var upgrade *modules.ChannelUpgrade
+		upgrade, clierr = modules.PlanModuleUpgrade(cfg.Ctx, client.Kyma(), cfg.module, cfg.channel)
+		if upgrade != nil {
+			upgrades = append(upgrades, *upgrade)
+		}
+	}
+	if clierr != nil {
+		return clierr
+	}
+
+	modules.RenderUpgrades(os.Stdout, upgrades)
+
+	clierr = modules.CheckDowngrades(upgrades, cfg.allowDowngrade)
+	if clierr != nil {
+		return clierr
+	}
+
+	if cfg.dryRun {
+		return nil
+	}
+
+	if cfg.all {
+		clierr = modules.UpgradeChannel(cfg.Ctx, client.Kyma(), cfg.channel)
+		if clierr != nil {
+			return clierr
+		}
+
+		fmt.Printf("Default channel of the Kyma CR changed to %s\n", cfg.channel)
+		return nil
+	}
+
+	clierr = modules.UpgradeModule(cfg.Ctx, client.Kyma(), cfg.module, cfg.channel)
+	if clierr != nil {
+		return clierr
+	}
+
+	fmt.Printf("Channel of the %s module changed to %s\n", cfg.module, cfg.channel)
+	return nil
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Format string
+
+const (
+	DefaultFormat Format = ""
+	TableFormat   Format = "table"
+	JSONFormat    Format = "json"
+	YAMLFormat    Format = "yaml"
+)
+
+var availableFormats = []Format{TableFormat, JSONFormat, YAMLFormat}
+
+func (f *Format) String() string {
+	return string(*f)
+}
+
+func (f *Format) Set(value string) error {
+	for _, format := range availableFormats {
+		if Format(value) == format {
+			*f = format
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid output format '%s', use one of: %s", value, strings.Join(formatsToStrings(availableFormats), ", "))
+}
+
+func (f *Format) Type() string {
+	return "string"
+}
+
+func formatsToStrings(formats []Format) []string {
+	values := make([]string, len(formats))
+	for i, format := range formats {
+		values[i] = string(format)
+	}
+
+	return values
+}
@@ -0,0 +1,52 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat_Set(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expected      types.Format
+		expectedError bool
+	}{
+		{
+			name:     "table",
+			value:    "table",
+			expected: types.TableFormat,
+		},
+		{
+			name:     "json",
+			value:    "json",
+			expected: types.JSONFormat,
+		},
+		{
+			name:     "yaml",
+			value:    "yaml",
+			expected: types.YAMLFormat,
+		},
+		{
+			name:          "incorrect",
+			value:         "xml",
+			expected:      types.DefaultFormat,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := types.Format("")
+			err := f.Set(tt.value)
+			if tt.expectedError {
+				require.ErrorContains(t, err, "invalid output format 'xml', use one of: table, json, yaml")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, f)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type ProbeProtocol string
+
+const (
+	HTTPProbe ProbeProtocol = "http"
+	TCPProbe  ProbeProtocol = "tcp"
+)
+
+// Probe is a flag describing a container probe in the format 'http:[<port>][<path>]' or 'tcp:[<port>]'
+// port equal to 0 means that the probe should use the container port
+type Probe struct {
+	Protocol ProbeProtocol
+	Port     int32
+	Path     string
+}
+
+func (p *Probe) IsSet() bool {
+	return p.Protocol != ""
+}
+
+func (p *Probe) String() string {
+	if !p.IsSet() {
+		return ""
+	}
+
+	value := string(p.Protocol) + ":"
+	if p.Port != 0 {
+		value += strconv.FormatInt(int64(p.Port), 10)
+	}
+	return value + p.Path
+}
+
+func (p *Probe) Set(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	protocol, rest, _ := strings.Cut(value, ":")
+	port, path := rest, ""
+	switch ProbeProtocol(protocol) {
+	case HTTPProbe:
+		if i := strings.Index(rest, "/"); i != -1 {
+			port, path = rest[:i], rest[i:]
+		}
+		if path == "" {
+			path = "/"
+		}
+	case TCPProbe:
+	default:
+		return fmt.Errorf("invalid probe protocol '%s', use one of: %s, %s", protocol, HTTPProbe, TCPProbe)
+	}
+
+	var portValue int64
+	if port != "" {
+		var err error
+		portValue, err = strconv.ParseInt(port, 10, 32)
+		if err != nil || portValue <= 0 {
+			return fmt.Errorf("invalid probe port '%s'", port)
+		}
+	}
+
+	p.Protocol = ProbeProtocol(protocol)
+	p.Port = int32(portValue)
+	p.Path = path
+	return nil
+}
+
+func (p *Probe) Type() string {
+	return "string"
+}
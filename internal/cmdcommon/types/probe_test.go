@@ -0,0 +1,84 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbe_Set(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expected      types.Probe
+		expectedError string
+	}{
+		{
+			name:     "empty",
+			value:    "",
+			expected: types.Probe{},
+		},
+		{
+			name:     "http with port and path",
+			value:    "http:8080/healthz",
+			expected: types.Probe{Protocol: types.HTTPProbe, Port: 8080, Path: "/healthz"},
+		},
+		{
+			name:     "http with path only",
+			value:    "http:/ready",
+			expected: types.Probe{Protocol: types.HTTPProbe, Path: "/ready"},
+		},
+		{
+			name:     "http with port only",
+			value:    "http:8080",
+			expected: types.Probe{Protocol: types.HTTPProbe, Port: 8080, Path: "/"},
+		},
+		{
+			name:     "tcp with port",
+			value:    "tcp:5432",
+			expected: types.Probe{Protocol: types.TCPProbe, Port: 5432},
+		},
+		{
+			name:     "tcp without port",
+			value:    "tcp",
+			expected: types.Probe{Protocol: types.TCPProbe},
+		},
+		{
+			name:          "unknown protocol",
+			value:         "grpc:9000",
+			expected:      types.Probe{},
+			expectedError: "invalid probe protocol 'grpc', use one of: http, tcp",
+		},
+		{
+			name:          "incorrect port",
+			value:         "tcp:port",
+			expected:      types.Probe{},
+			expectedError: "invalid probe port 'port'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := types.Probe{}
+			err := p.Set(tt.value)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expected, p)
+		})
+	}
+}
+
+func TestProbe_String(t *testing.T) {
+	p := types.Probe{}
+	require.Equal(t, "", p.String())
+
+	require.NoError(t, p.Set("http:8080/healthz"))
+	require.Equal(t, "http:8080/healthz", p.String())
+
+	require.NoError(t, p.Set("tcp"))
+	require.Equal(t, "tcp:", p.String())
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/yaml.v3"
+)
+
+// RenderFormat renders the object in the json or yaml format
+// the renderDefault function renders the object if the format is empty or table
+func RenderFormat(writer io.Writer, obj interface{}, format Format, renderDefault func(io.Writer)) error {
+	switch format {
+	case JSONFormat:
+		return RenderJSON(writer, obj)
+	case YAMLFormat:
+		return RenderYAML(writer, obj)
+	default:
+		renderDefault(writer)
+		return nil
+	}
+}
+
+// RenderJSON renders the object as indented json
+func RenderJSON(writer io.Writer, obj interface{}) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(obj)
+}
+
+// RenderYAML renders the object as a yaml document
+func RenderYAML(writer io.Writer, obj interface{}) error {
+	encoder := yaml.NewEncoder(writer)
+	encoder.SetIndent(2)
+	defer encoder.Close()
+	return encoder.Encode(obj)
+}
+
+// RenderTable renders rows as a table with left-aligned columns separated by tabs
+func RenderTable(writer io.Writer, rows [][]string, headers []string) {
+	table := tablewriter.NewWriter(writer)
+	table.SetRowLine(false)
+	table.SetHeaderLine(false)
+	table.SetColumnSeparator("")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetBorder(false)
+	table.SetTablePadding("\t")
+	table.SetNoWhiteSpace(true)
+	table.AppendBulk(rows)
+	table.SetHeader(headers)
+	table.Render()
+}
+
+// ValueOrDash returns the value or '-' if it's empty
+func ValueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,53 @@
+package types_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderFormat(t *testing.T) {
+	obj := map[string]string{"name": "test"}
+	renderDefault := func(writer io.Writer) {
+		types.RenderTable(writer, [][]string{{"test", types.ValueOrDash("")}}, []string{"NAME", "URL"})
+	}
+
+	tests := []struct {
+		name     string
+		format   types.Format
+		expected string
+	}{
+		{
+			name:     "json",
+			format:   types.JSONFormat,
+			expected: "{\n  \"name\": \"test\"\n}\n",
+		},
+		{
+			name:     "yaml",
+			format:   types.YAMLFormat,
+			expected: "name: test\n",
+		},
+		{
+			name:     "table",
+			format:   types.TableFormat,
+			expected: "NAME\tURL \ntest\t-  \t\n",
+		},
+		{
+			name:     "empty format",
+			format:   types.DefaultFormat,
+			expected: "NAME\tURL \ntest\t-  \t\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+
+			err := types.RenderFormat(buffer, obj, tt.format, renderDefault)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, buffer.String())
+		})
+	}
+}
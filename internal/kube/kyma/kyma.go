@@ -2,26 +2,51 @@ package kyma
 
 import (
 	"context"
+	"encoding/json"
 	"slices"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 )
 
 const (
 	DefaultKymaName      = "default"
 	DefaultKymaNamespace = "kyma-system"
+
+	// listPageSize limits number of resources returned by a single list request
+	listPageSize int64 = 100
 )
 
+// Filter decides if the listed resource should be returned
+// it's called before the resource is converted to the structured type
+type Filter func(*unstructured.Unstructured) bool
+
+// ByModuleName returns filter accepting resources of modules with given names
+// all resources are accepted if names are empty
+func ByModuleName(names ...string) Filter {
+	return func(u *unstructured.Unstructured) bool {
+		if len(names) == 0 {
+			return true
+		}
+
+		moduleName, _, _ := unstructured.NestedString(u.Object, "spec", "moduleName")
+		return slices.Contains(names, moduleName)
+	}
+}
+
 type Interface interface {
-	ListModuleReleaseMeta(context.Context) (*ModuleReleaseMetaList, error)
-	ListModuleTemplate(context.Context) (*ModuleTemplateList, error)
+	ListModuleReleaseMeta(context.Context, ...Filter) (*ModuleReleaseMetaList, error)
+	ListModuleTemplate(context.Context, ...Filter) (*ModuleTemplateList, error)
 	GetDefaultKyma(context.Context) (*Kyma, error)
-	UpdateDefaultKyma(context.Context, *Kyma) error
-	EnableModule(context.Context, string, string) error
+	PatchDefaultKyma(context.Context, func(*Kyma) error) error
+	EnableModule(context.Context, string, string, string) error
 	DisableModule(context.Context, string) error
 }
 
@@ -36,13 +61,15 @@ func NewClient(dynamic dynamic.Interface) Interface {
 }
 
 // ListModuleReleaseMeta lists ModuleReleaseMeta resources from across the whole cluster
-func (c *client) ListModuleReleaseMeta(ctx context.Context) (*ModuleReleaseMetaList, error) {
-	return list[ModuleReleaseMetaList](ctx, c.dynamic, GVRModuleReleaseMeta)
+// only resources accepted by all filters are returned
+func (c *client) ListModuleReleaseMeta(ctx context.Context, filters ...Filter) (*ModuleReleaseMetaList, error) {
+	return list[ModuleReleaseMetaList](ctx, c.dynamic, GVRModuleReleaseMeta, filters)
 }
 
 // ListModuleTemplate lists ModuleTemplate resources from across the whole cluster
-func (c *client) ListModuleTemplate(ctx context.Context) (*ModuleTemplateList, error) {
-	return list[ModuleTemplateList](ctx, c.dynamic, GVRModuleTemplate)
+// only resources accepted by all filters are returned
+func (c *client) ListModuleTemplate(ctx context.Context, filters ...Filter) (*ModuleTemplateList, error) {
+	return list[ModuleTemplateList](ctx, c.dynamic, GVRModuleTemplate, filters)
 }
 
 // GetDefaultKyma gets the default Kyma CR from the kyma-system namespace and cast it to the Kyma structure
@@ -60,57 +87,145 @@ func (c *client) GetDefaultKyma(ctx context.Context) (*Kyma, error) {
 	return kyma, err
 }
 
-// UpdateDefaultKyma updates the default Kyma CR from the kyma-system namespace based on the Kyma CR from arguments
-func (c *client) UpdateDefaultKyma(ctx context.Context, obj *Kyma) error {
-	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
-	if err != nil {
-		return err
-	}
+// PatchDefaultKyma modifies the default Kyma CR from the kyma-system namespace using the modify func
+// only changed spec.channel and spec.modules fields are sent in the JSON merge patch
+// fields of modules not modeled by the Module struct are preserved
+// the patch is retried with the latest Kyma CR if it was modified in the meantime
+func (c *client) PatchDefaultKyma(ctx context.Context, modify func(*Kyma) error) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		u, err := c.dynamic.Resource(GVRKyma).
+			Namespace(DefaultKymaNamespace).
+			Get(ctx, DefaultKymaName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
 
-	_, err = c.dynamic.Resource(GVRKyma).
-		Namespace(DefaultKymaNamespace).
-		Update(ctx, &unstructured.Unstructured{Object: u}, metav1.UpdateOptions{})
+		kymaCR := &Kyma{}
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, kymaCR)
+		if err != nil {
+			return err
+		}
+
+		err = modify(kymaCR)
+		if err != nil {
+			return err
+		}
+
+		patch, err := buildSpecPatch(u, kymaCR)
+		if err != nil || patch == nil {
+			return err
+		}
+
+		_, err = c.dynamic.Resource(GVRKyma).
+			Namespace(DefaultKymaNamespace).
+			Patch(ctx, DefaultKymaName, types.MergePatchType, patch, metav1.PatchOptions{})
 
-	return err
+		return err
+	})
 }
 
 // EnableModule adds module to the default Kyma CR in the kyma-system namespace
 // if moduleChannel is empty it uses default channel in the Kyma CR
-func (c *client) EnableModule(ctx context.Context, moduleName, moduleChannel string) error {
-	kymaCR, err := c.GetDefaultKyma(ctx)
+// if customResourcePolicy is empty it uses default policy of the lifecycle-manager
+func (c *client) EnableModule(ctx context.Context, moduleName, moduleChannel, customResourcePolicy string) error {
+	return c.PatchDefaultKyma(ctx, func(kymaCR *Kyma) error {
+		enableModule(kymaCR, moduleName, moduleChannel, customResourcePolicy)
+		return nil
+	})
+}
+
+// DisableModule removes module from the default Kyma CR in the kyma-system namespace
+func (c *client) DisableModule(ctx context.Context, moduleName string) error {
+	return c.PatchDefaultKyma(ctx, func(kymaCR *Kyma) error {
+		disableModule(kymaCR, moduleName)
+		return nil
+	})
+}
+
+// buildSpecPatch returns JSON merge patch with changes of the spec.channel and spec.modules fields
+// or nil if there is nothing to change
+// the patch contains resourceVersion of the original CR so it fails with a conflict if the CR was modified in the meantime
+func buildSpecPatch(original *unstructured.Unstructured, kymaCR *Kyma) ([]byte, error) {
+	specPatch := map[string]interface{}{}
+
+	channel, _, _ := unstructured.NestedString(original.Object, "spec", "channel")
+	if channel != kymaCR.Spec.Channel {
+		specPatch["channel"] = kymaCR.Spec.Channel
+		if kymaCR.Spec.Channel == "" {
+			specPatch["channel"] = nil
+		}
+	}
+
+	originalModules, _, _ := unstructured.NestedSlice(original.Object, "spec", "modules")
+	modules, err := mergeModules(originalModules, kymaCR.Spec.Modules)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if !equality.Semantic.DeepEqual(originalModules, modules) {
+		specPatch["modules"] = modules
 	}
 
-	kymaCR = enableModule(kymaCR, moduleName, moduleChannel)
+	if len(specPatch) == 0 {
+		return nil, nil
+	}
 
-	return c.UpdateDefaultKyma(ctx, kymaCR)
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": original.GetResourceVersion(),
+		},
+		"spec": specPatch,
+	})
 }
 
-// DisableModule removes module from the default Kyma CR in the kyma-system namespace
-func (c *client) DisableModule(ctx context.Context, moduleName string) error {
-	kymaCR, err := c.GetDefaultKyma(ctx)
-	if err != nil {
-		return err
+// mergeModules converts modules to the unstructured form
+// fields unknown for the Module struct are copied from the original module with the same name
+func mergeModules(originalModules []interface{}, modules []Module) ([]interface{}, error) {
+	merged := []interface{}{}
+	for _, module := range modules {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&module)
+		if err != nil {
+			return nil, err
+		}
+
+		original := findUnstructuredModule(originalModules, module.Name)
+		for key, value := range original {
+			if !slices.Contains(knownModuleFields, key) {
+				u[key] = value
+			}
+		}
+
+		merged = append(merged, u)
 	}
 
-	kymaCR = disableModule(kymaCR, moduleName)
+	return merged, nil
+}
 
-	return c.UpdateDefaultKyma(ctx, kymaCR)
+func findUnstructuredModule(modules []interface{}, name string) map[string]interface{} {
+	for _, module := range modules {
+		moduleMap, ok := module.(map[string]interface{})
+		if ok && moduleMap["name"] == name {
+			return moduleMap
+		}
+	}
+
+	return nil
 }
 
-func enableModule(kymaCR *Kyma, moduleName, moduleChannel string) *Kyma {
+func enableModule(kymaCR *Kyma, moduleName, moduleChannel, customResourcePolicy string) *Kyma {
 	for i, m := range kymaCR.Spec.Modules {
 		if m.Name == moduleName {
-			// module already exists, update channel
+			// module already exists, update channel and policy
 			kymaCR.Spec.Modules[i].Channel = moduleChannel
+			kymaCR.Spec.Modules[i].CustomResourcePolicy = customResourcePolicy
 			return kymaCR
 		}
 	}
 
 	kymaCR.Spec.Modules = append(kymaCR.Spec.Modules, Module{
-		Name:    moduleName,
-		Channel: moduleChannel,
+		Name:                 moduleName,
+		Channel:              moduleChannel,
+		CustomResourcePolicy: customResourcePolicy,
+		Managed:              ptr.To(true),
 	})
 
 	return kymaCR
@@ -124,14 +239,44 @@ func disableModule(kymaCR *Kyma, moduleName string) *Kyma {
 	return kymaCR
 }
 
-func list[T any](ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource) (*T, error) {
-	list, err := client.Resource(gvr).
-		List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, err
+// list gets resources page by page and converts those accepted by filters to the structured list
+func list[T any](ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource, filters []Filter) (*T, error) {
+	list := &unstructured.UnstructuredList{}
+	opts := metav1.ListOptions{Limit: listPageSize}
+	for {
+		page, err := client.Resource(gvr).
+			List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if list.Object == nil {
+			list.Object = page.Object
+		}
+
+		for i := range page.Items {
+			if accept(&page.Items[i], filters) {
+				list.Items = append(list.Items, page.Items[i])
+			}
+		}
+
+		opts.Continue = page.GetContinue()
+		if opts.Continue == "" {
+			break
+		}
 	}
 
 	structuredList := new(T)
-	err = runtime.DefaultUnstructuredConverter.FromUnstructured(list.UnstructuredContent(), structuredList)
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.UnstructuredContent(), structuredList)
 	return structuredList, err
 }
+
+func accept(u *unstructured.Unstructured, filters []Filter) bool {
+	for _, filter := range filters {
+		if !filter(u) {
+			return false
+		}
+	}
+
+	return true
+}
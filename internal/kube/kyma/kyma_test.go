@@ -3,14 +3,21 @@ package kyma
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	dynamic_fake "k8s.io/client-go/dynamic/fake"
+	clientgo_testing "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
 )
 
 func TestGetDefaultKyma(t *testing.T) {
@@ -54,46 +61,116 @@ func TestGetDefaultKyma(t *testing.T) {
 	})
 }
 
-func TestUpdateDefaultKyma(t *testing.T) {
-	t.Run("update kyma", func(t *testing.T) {
+func TestPatchDefaultKyma(t *testing.T) {
+	t.Run("enable module and preserve unknown fields", func(t *testing.T) {
 		scheme := runtime.NewScheme()
 		scheme.AddKnownTypes(GVRKyma.GroupVersion())
-		dynamic := dynamic_fake.NewSimpleDynamicClient(scheme, fixDefaultKyma())
+		dynamic := dynamic_fake.NewSimpleDynamicClient(scheme, fixDefaultKymaWithUnknownFields())
 		client := NewClient(dynamic)
 
-		expectedKyma := &Kyma{
-			TypeMeta: v1.TypeMeta{
-				APIVersion: "operator.kyma-project.io/v1beta2",
-				Kind:       "Kyma",
-			},
-			ObjectMeta: v1.ObjectMeta{
-				Name:      "default",
-				Namespace: "kyma-system",
-			},
-			Spec: KymaSpec{
-				Channel: "fast",
-				Modules: []Module{
-					{
-						Name:    "test-module",
-						Channel: "regular",
-					},
-					{
-						Name: "another-test-module",
-					},
+		err := client.EnableModule(context.Background(), "test-module", "regular", "")
+		require.NoError(t, err)
+		err = client.EnableModule(context.Background(), "another-test-module", "", CustomResourcePolicyIgnore)
+		require.NoError(t, err)
+
+		u, err := dynamic.Resource(GVRKyma).Namespace("kyma-system").Get(context.Background(), "default", v1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"channel": "fast",
+			"unknown": "value",
+			"modules": []interface{}{
+				map[string]interface{}{
+					"name":    "test-module",
+					"channel": "regular",
+					"managed": true,
+					"unknown": "value",
+				},
+				map[string]interface{}{
+					"name":                 "another-test-module",
+					"customResourcePolicy": "Ignore",
+					"managed":              true,
 				},
 			},
-		}
+		}, u.Object["spec"])
+	})
+
+	t.Run("disable module and preserve unknown fields", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		scheme.AddKnownTypes(GVRKyma.GroupVersion())
+		dynamic := dynamic_fake.NewSimpleDynamicClient(scheme, fixDefaultKymaWithUnknownFields())
+		client := NewClient(dynamic)
 
-		err := client.UpdateDefaultKyma(context.Background(), expectedKyma)
+		err := client.DisableModule(context.Background(), "test-module")
 		require.NoError(t, err)
 
 		u, err := dynamic.Resource(GVRKyma).Namespace("kyma-system").Get(context.Background(), "default", v1.GetOptions{})
 		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"channel": "fast",
+			"unknown": "value",
+			"modules": []interface{}{},
+		}, u.Object["spec"])
+	})
+
+	t.Run("keep managed unset for untouched modules", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		scheme.AddKnownTypes(GVRKyma.GroupVersion())
+		dynamic := dynamic_fake.NewSimpleDynamicClient(scheme, fixDefaultKyma())
+		client := NewClient(dynamic)
 
-		kyma := &Kyma{}
-		err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, kyma)
+		err := client.EnableModule(context.Background(), "another-test-module", "", "")
 		require.NoError(t, err)
-		require.Equal(t, expectedKyma, kyma)
+
+		u, err := dynamic.Resource(GVRKyma).Namespace("kyma-system").Get(context.Background(), "default", v1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{
+			map[string]interface{}{
+				"name": "test-module",
+			},
+			map[string]interface{}{
+				"name":    "another-test-module",
+				"managed": true,
+			},
+		}, u.Object["spec"].(map[string]interface{})["modules"])
+	})
+
+	t.Run("retry on conflict", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		scheme.AddKnownTypes(GVRKyma.GroupVersion())
+		dynamic := dynamic_fake.NewSimpleDynamicClient(scheme, fixDefaultKyma())
+		patches := 0
+		dynamic.PrependReactor("patch", "kymas", func(_ clientgo_testing.Action) (bool, runtime.Object, error) {
+			patches++
+			if patches == 1 {
+				return true, nil, apierrors.NewConflict(GVRKyma.GroupResource(), "default", errors.New("object was modified"))
+			}
+			return false, nil, nil
+		})
+		client := NewClient(dynamic)
+
+		err := client.EnableModule(context.Background(), "another-test-module", "", "")
+		require.NoError(t, err)
+		require.Equal(t, 2, patches)
+
+		kyma, err := client.GetDefaultKyma(context.Background())
+		require.NoError(t, err)
+		require.Len(t, kyma.Spec.Modules, 2)
+	})
+
+	t.Run("skip patch when nothing changed", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		scheme.AddKnownTypes(GVRKyma.GroupVersion())
+		dynamic := dynamic_fake.NewSimpleDynamicClient(scheme, fixDefaultKymaWithUnknownFields())
+		client := NewClient(dynamic)
+
+		err := client.PatchDefaultKyma(context.Background(), func(_ *Kyma) error {
+			return nil
+		})
+		require.NoError(t, err)
+
+		for _, action := range dynamic.Actions() {
+			require.NotEqual(t, "patch", action.GetVerb())
+		}
 	})
 
 	t.Run("kyma not found", func(t *testing.T) {
@@ -101,31 +178,50 @@ func TestUpdateDefaultKyma(t *testing.T) {
 		scheme.AddKnownTypes(GVRKyma.GroupVersion())
 		client := NewClient(dynamic_fake.NewSimpleDynamicClient(scheme))
 
-		expectedKyma := &Kyma{
-			TypeMeta: v1.TypeMeta{
-				APIVersion: "operator.kyma-project.io/v1beta2",
-				Kind:       "Kyma",
-			},
-			ObjectMeta: v1.ObjectMeta{
-				Name:      "default",
-				Namespace: "kyma-system",
+		err := client.DisableModule(context.Background(), "test-module")
+		require.ErrorContains(t, err, "not found")
+	})
+}
+
+func Test_buildSpecPatch(t *testing.T) {
+	t.Run("patch removed channel", func(t *testing.T) {
+		original := fixDefaultKyma()
+		original.SetResourceVersion("123")
+
+		patch, err := buildSpecPatch(original, &Kyma{
+			Spec: KymaSpec{
+				Modules: []Module{
+					{
+						Name: "test-module",
+					},
+				},
 			},
+		})
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"metadata": {"resourceVersion": "123"},
+			"spec": {
+				"channel": null
+			}
+		}`, string(patch))
+	})
+
+	t.Run("nothing to patch", func(t *testing.T) {
+		original := fixDefaultKymaWithUnknownFields()
+
+		patch, err := buildSpecPatch(original, &Kyma{
 			Spec: KymaSpec{
 				Channel: "fast",
 				Modules: []Module{
 					{
 						Name:    "test-module",
-						Channel: "regular",
-					},
-					{
-						Name: "another-test-module",
+						Managed: ptr.To(true),
 					},
 				},
 			},
-		}
-
-		err := client.UpdateDefaultKyma(context.Background(), expectedKyma)
-		require.ErrorContains(t, err, "not found")
+		})
+		require.NoError(t, err)
+		require.Nil(t, patch)
 	})
 }
 
@@ -216,6 +312,7 @@ func Test_updateCR(t *testing.T) {
 		kymaCR     *Kyma
 		moduleName string
 		channel    string
+		crPolicy   string
 		want       *Kyma
 	}{
 		{
@@ -261,7 +358,8 @@ func Test_updateCR(t *testing.T) {
 							Name: "istio",
 						},
 						{
-							Name: "module",
+							Name:    "module",
+							Managed: ptr.To(true),
 						},
 					},
 				},
@@ -289,6 +387,7 @@ func Test_updateCR(t *testing.T) {
 						{
 							Name:    "module",
 							Channel: "channel",
+							Managed: ptr.To(true),
 						},
 					},
 				},
@@ -318,6 +417,56 @@ func Test_updateCR(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:       "added module with custom resource policy",
+			moduleName: "module",
+			channel:    "",
+			crPolicy:   CustomResourcePolicyIgnore,
+			kymaCR: &Kyma{
+				Spec: KymaSpec{
+					Modules: []Module{},
+				},
+			},
+			want: &Kyma{
+				Spec: KymaSpec{
+					Modules: []Module{
+						{
+							Name:                 "module",
+							CustomResourcePolicy: CustomResourcePolicyIgnore,
+							Managed:              ptr.To(true),
+						},
+					},
+				},
+			},
+		},
+		{
+			name:       "changed custom resource policy of existing module",
+			moduleName: "module",
+			channel:    "channel",
+			crPolicy:   CustomResourcePolicyCreateAndDelete,
+			kymaCR: &Kyma{
+				Spec: KymaSpec{
+					Modules: []Module{
+						{
+							Name:                 "module",
+							Channel:              "channel",
+							CustomResourcePolicy: CustomResourcePolicyIgnore,
+						},
+					},
+				},
+			},
+			want: &Kyma{
+				Spec: KymaSpec{
+					Modules: []Module{
+						{
+							Name:                 "module",
+							Channel:              "channel",
+							CustomResourcePolicy: CustomResourcePolicyCreateAndDelete,
+						},
+					},
+				},
+			},
+		},
 		{
 			name:       "removed channel from existing module",
 			moduleName: "module",
@@ -347,9 +496,10 @@ func Test_updateCR(t *testing.T) {
 		kymaCR := tt.kymaCR
 		moduleName := tt.moduleName
 		moduleChannel := tt.channel
+		crPolicy := tt.crPolicy
 		want := tt.want
 		t.Run(tt.name, func(t *testing.T) {
-			got := enableModule(kymaCR, moduleName, moduleChannel)
+			got := enableModule(kymaCR, moduleName, moduleChannel, crPolicy)
 			gotBytes, err := json.Marshal(got)
 			require.NoError(t, err)
 			wantBytes, err := json.Marshal(want)
@@ -388,6 +538,30 @@ func fixDefaultKyma() *unstructured.Unstructured {
 	}
 }
 
+func fixDefaultKymaWithUnknownFields() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "operator.kyma-project.io/v1beta2",
+			"kind":       "Kyma",
+			"metadata": map[string]interface{}{
+				"name":      "default",
+				"namespace": "kyma-system",
+			},
+			"spec": map[string]interface{}{
+				"channel": "fast",
+				"unknown": "value",
+				"modules": []interface{}{
+					map[string]interface{}{
+						"name":    "test-module",
+						"managed": true,
+						"unknown": "value",
+					},
+				},
+			},
+		},
+	}
+}
+
 func Test_client_ListModuleReleaseMeta(t *testing.T) {
 	t.Run("list ModuleReleaseMeta", func(t *testing.T) {
 		scheme := runtime.NewScheme()
@@ -424,6 +598,27 @@ func Test_client_ListModuleTemplate(t *testing.T) {
 		require.Contains(t, list.Items, fixModuleTemplateStruct("test-2"))
 
 	})
+
+	t.Run("list all pages", func(t *testing.T) {
+		dynamic := &fakePagedDynamic{
+			pages: [][]unstructured.Unstructured{
+				{fixVersionedModuleTemplate("serverless", "1.0.0"), fixVersionedModuleTemplate("keda", "1.0.0")},
+				{fixVersionedModuleTemplate("serverless", "1.1.0")},
+			},
+		}
+		client := NewClient(dynamic)
+
+		moduleTemplates, err := client.ListModuleTemplate(context.Background(), ByModuleName("serverless"))
+		require.NoError(t, err)
+		require.Len(t, moduleTemplates.Items, 2)
+		require.Equal(t, "1.0.0", moduleTemplates.Items[0].Spec.Version)
+		require.Equal(t, "1.1.0", moduleTemplates.Items[1].Spec.Version)
+
+		require.Equal(t, []v1.ListOptions{
+			{Limit: listPageSize},
+			{Limit: listPageSize, Continue: "1"},
+		}, dynamic.listOptions)
+	})
 }
 
 func fixModuleReleaseMetaStruct(moduleName string) ModuleReleaseMeta {
@@ -511,3 +706,49 @@ func fixModuleTemplate(moduleName string) *unstructured.Unstructured {
 		},
 	}
 }
+
+func fixVersionedModuleTemplate(moduleName, version string) unstructured.Unstructured {
+	return unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "operator.kyma-project.io/v1beta2",
+			"kind":       "ModuleTemplate",
+			"metadata": map[string]interface{}{
+				"name":      moduleName + "-" + version,
+				"namespace": "kyma-system",
+			},
+			"spec": map[string]interface{}{
+				"moduleName": moduleName,
+				"version":    version,
+			},
+		},
+	}
+}
+
+// fakePagedDynamic returns pages of resources using the page index as the continue token
+// the dynamic fake client doesn't support pagination
+type fakePagedDynamic struct {
+	dynamic.Interface
+	dynamic.NamespaceableResourceInterface
+
+	pages       [][]unstructured.Unstructured
+	listOptions []v1.ListOptions
+}
+
+func (f *fakePagedDynamic) Resource(_ schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return f
+}
+
+func (f *fakePagedDynamic) List(_ context.Context, opts v1.ListOptions) (*unstructured.UnstructuredList, error) {
+	f.listOptions = append(f.listOptions, opts)
+
+	page := 0
+	if opts.Continue != "" {
+		page, _ = strconv.Atoi(opts.Continue)
+	}
+
+	list := &unstructured.UnstructuredList{Object: map[string]interface{}{}, Items: f.pages[page]}
+	if page+1 < len(f.pages) {
+		list.SetContinue(strconv.Itoa(page + 1))
+	}
+	return list, nil
+}
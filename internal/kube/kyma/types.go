@@ -114,9 +114,23 @@ type Module struct {
 	ControllerName       string `json:"controller,omitempty"`
 	Channel              string `json:"channel,omitempty"`
 	CustomResourcePolicy string `json:"customResourcePolicy,omitempty"`
-	Managed              bool   `json:"managed,omitempty"`
+	Managed              *bool  `json:"managed,omitempty"`
 }
 
+// IsManaged returns true if the module is managed by the lifecycle-manager
+// the module is managed if the field is not set, the same as the lifecycle-manager default
+func (m Module) IsManaged() bool {
+	return m.Managed == nil || *m.Managed
+}
+
+// fields of the module entry in the Kyma CR modeled by the Module struct
+var knownModuleFields = []string{"name", "controller", "channel", "customResourcePolicy", "managed"}
+
+const (
+	CustomResourcePolicyCreateAndDelete = "CreateAndDelete"
+	CustomResourcePolicyIgnore          = "Ignore"
+)
+
 // KymaStatus defines the observed state of Kyma
 type KymaStatus struct {
 	Modules []ModuleStatus `json:"modules,omitempty"`
@@ -129,6 +143,14 @@ type ModuleStatus struct {
 	State   string `json:"state,omitempty"`
 }
 
+const (
+	ModuleStateReady      = "Ready"
+	ModuleStateProcessing = "Processing"
+	ModuleStateDeleting   = "Deleting"
+	ModuleStateWarning    = "Warning"
+	ModuleStateError      = "Error"
+)
+
 // ModuleFromInterface converts a map retrieved from the Unstructured kyma CR to a Module struct.
 func ModuleFromInterface(i map[string]interface{}) Module {
 	module := Module{Name: i["name"].(string)}
@@ -0,0 +1,133 @@
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+var defaultAPIRuleMethods = []v2alpha1.HttpMethod{"GET", "POST", "PUT", "DELETE", "PATCH"}
+
+// APIRuleAuth describes the access strategy of the default APIRule rule exposing all paths of the app
+// requests are not authorized if nothing is set
+// OAuth2 introspection is configured with the external authorizer, for example oauth2-proxy registered in Istio
+type APIRuleAuth struct {
+	JwtIssuer         string
+	JwtJwksURI        string
+	JwtRequiredScopes []string
+	ExtAuthorizers    []string
+}
+
+// apiRuleRulesFile is the format of the file with APIRule rules
+type apiRuleRulesFile struct {
+	Rules []v2alpha1.Rule `json:"rules"`
+}
+
+// BuildDefaultAPIRuleRules returns the rule exposing all paths and methods of the app with the given access strategy
+// JWT is used as restrictions of the external authorizers if both are set
+func BuildDefaultAPIRuleRules(auth APIRuleAuth) []v2alpha1.Rule {
+	rule := v2alpha1.Rule{
+		Path:    "/*",
+		Methods: defaultAPIRuleMethods,
+	}
+
+	jwt := buildJwtConfig(auth)
+	switch {
+	case len(auth.ExtAuthorizers) > 0:
+		rule.ExtAuth = &v2alpha1.ExtAuth{
+			ExternalAuthorizers: auth.ExtAuthorizers,
+			Restrictions:        jwt,
+		}
+	case jwt != nil:
+		rule.Jwt = jwt
+	default:
+		rule.NoAuth = ptr.To(true)
+	}
+
+	return []v2alpha1.Rule{rule}
+}
+
+func buildJwtConfig(auth APIRuleAuth) *v2alpha1.JwtConfig {
+	if auth.JwtIssuer == "" {
+		return nil
+	}
+
+	jwt := &v2alpha1.JwtConfig{
+		Authentications: []*v2alpha1.JwtAuthentication{
+			{
+				Issuer:  auth.JwtIssuer,
+				JwksUri: auth.JwtJwksURI,
+			},
+		},
+	}
+	if len(auth.JwtRequiredScopes) > 0 {
+		jwt.Authorizations = []*v2alpha1.JwtAuthorization{
+			{RequiredScopes: auth.JwtRequiredScopes},
+		}
+	}
+
+	return jwt
+}
+
+// ReadAPIRuleRulesFile reads per-path and per-method rules in the v2alpha1 APIRule format from the yaml file
+// the file contains the 'rules' list, every rule must have exactly one access strategy: noAuth, jwt or extAuth
+func ReadAPIRuleRulesFile(path string) ([]v2alpha1.Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rulesFile := apiRuleRulesFile{}
+	err = yaml.UnmarshalStrict(data, &rulesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rulesFile.Rules) == 0 {
+		return nil, errors.New("no rules found")
+	}
+
+	for i, rule := range rulesFile.Rules {
+		err = validateAPIRuleRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %d: %w", i+1, err)
+		}
+	}
+
+	return rulesFile.Rules, nil
+}
+
+func validateAPIRuleRule(rule v2alpha1.Rule) error {
+	if rule.Path == "" {
+		return errors.New("path is required")
+	}
+	if len(rule.Methods) == 0 {
+		return errors.New("at least one method is required")
+	}
+
+	strategies := 0
+	if rule.NoAuth != nil && *rule.NoAuth {
+		strategies++
+	}
+	if rule.Jwt != nil {
+		strategies++
+		if len(rule.Jwt.Authentications) == 0 {
+			return errors.New("jwt requires at least one authentication")
+		}
+	}
+	if rule.ExtAuth != nil {
+		strategies++
+		if len(rule.ExtAuth.ExternalAuthorizers) == 0 {
+			return errors.New("extAuth requires at least one authorizer")
+		}
+	}
+	if strategies != 1 {
+		return errors.New("exactly one of noAuth, jwt or extAuth is required")
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package resources
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildDefaultAPIRuleRules(t *testing.T) {
+	tests := []struct {
+		name string
+		auth APIRuleAuth
+		want v2alpha1.Rule
+	}{
+		{
+			name: "no auth",
+			auth: APIRuleAuth{},
+			want: v2alpha1.Rule{
+				Path:    "/*",
+				Methods: defaultAPIRuleMethods,
+				NoAuth:  ptr.To(true),
+			},
+		},
+		{
+			name: "jwt with required scopes",
+			auth: APIRuleAuth{
+				JwtIssuer:         "https://issuer.example.com",
+				JwtJwksURI:        "https://issuer.example.com/keys",
+				JwtRequiredScopes: []string{"read", "write"},
+			},
+			want: v2alpha1.Rule{
+				Path:    "/*",
+				Methods: defaultAPIRuleMethods,
+				Jwt: &v2alpha1.JwtConfig{
+					Authentications: []*v2alpha1.JwtAuthentication{
+						{Issuer: "https://issuer.example.com", JwksUri: "https://issuer.example.com/keys"},
+					},
+					Authorizations: []*v2alpha1.JwtAuthorization{
+						{RequiredScopes: []string{"read", "write"}},
+					},
+				},
+			},
+		},
+		{
+			name: "ext auth with jwt restrictions",
+			auth: APIRuleAuth{
+				JwtIssuer:      "https://issuer.example.com",
+				JwtJwksURI:     "https://issuer.example.com/keys",
+				ExtAuthorizers: []string{"oauth2-proxy"},
+			},
+			want: v2alpha1.Rule{
+				Path:    "/*",
+				Methods: defaultAPIRuleMethods,
+				ExtAuth: &v2alpha1.ExtAuth{
+					ExternalAuthorizers: []string{"oauth2-proxy"},
+					Restrictions: &v2alpha1.JwtConfig{
+						Authentications: []*v2alpha1.JwtAuthentication{
+							{Issuer: "https://issuer.example.com", JwksUri: "https://issuer.example.com/keys"},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, []v2alpha1.Rule{tt.want}, BuildDefaultAPIRuleRules(tt.auth))
+		})
+	}
+}
+
+func TestReadAPIRuleRulesFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		want          []v2alpha1.Rule
+		expectedError string
+	}{
+		{
+			name: "per-path and per-method rules",
+			content: `rules:
+- path: /public/{**}
+  methods: [GET]
+  noAuth: true
+- path: /admin/{**}
+  methods: [GET, POST]
+  jwt:
+    authentications:
+    - issuer: https://issuer.example.com
+      jwksUri: https://issuer.example.com/keys
+    authorizations:
+    - requiredScopes: [admin]
+- path: /internal/{**}
+  methods: [POST]
+  extAuth:
+    authorizers: [oauth2-proxy]
+`,
+			want: []v2alpha1.Rule{
+				{
+					Path:    "/public/{**}",
+					Methods: []v2alpha1.HttpMethod{"GET"},
+					NoAuth:  ptr.To(true),
+				},
+				{
+					Path:    "/admin/{**}",
+					Methods: []v2alpha1.HttpMethod{"GET", "POST"},
+					Jwt: &v2alpha1.JwtConfig{
+						Authentications: []*v2alpha1.JwtAuthentication{
+							{Issuer: "https://issuer.example.com", JwksUri: "https://issuer.example.com/keys"},
+						},
+						Authorizations: []*v2alpha1.JwtAuthorization{
+							{RequiredScopes: []string{"admin"}},
+						},
+					},
+				},
+				{
+					Path:    "/internal/{**}",
+					Methods: []v2alpha1.HttpMethod{"POST"},
+					ExtAuth: &v2alpha1.ExtAuth{
+						ExternalAuthorizers: []string{"oauth2-proxy"},
+					},
+				},
+			},
+		},
+		{
+			name:          "no rules",
+			content:       "rules: []\n",
+			expectedError: "no rules found",
+		},
+		{
+			name:          "unknown field",
+			content:       "rules:\n- path: /*\n  methods: [GET]\n  noAuth: true\n  oauth2: true\n",
+			expectedError: "error unmarshaling JSON: while decoding JSON: json: unknown field \"oauth2\"",
+		},
+		{
+			name:          "missing methods",
+			content:       "rules:\n- path: /*\n  noAuth: true\n",
+			expectedError: "invalid rule 1: at least one method is required",
+		},
+		{
+			name: "many access strategies",
+			content: `rules:
+- path: /*
+  methods: [GET]
+  noAuth: true
+  extAuth:
+    authorizers: [oauth2-proxy]
+`,
+			expectedError: "invalid rule 1: exactly one of noAuth, jwt or extAuth is required",
+		},
+		{
+			name:          "no access strategy",
+			content:       "rules:\n- path: /*\n  methods: [GET]\n",
+			expectedError: "invalid rule 1: exactly one of noAuth, jwt or extAuth is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			got, err := ReadAPIRuleRulesFile(path)
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
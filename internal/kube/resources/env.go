@@ -0,0 +1,167 @@
+package resources
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kyma-project/cli.v3/internal/kube/rootlessdynamic"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Mount describes the ConfigMap or Secret mounted as files under the path in the app container
+type Mount struct {
+	Name string
+	Path string
+}
+
+// EnvObjectName returns name of the ConfigMap or Secret generated for variables of the app
+func EnvObjectName(appName string) string {
+	return appName + "-env"
+}
+
+// VCAPObjectName returns name of the Secret with credentials of services bound to the app in the VCAP_SERVICES variable
+func VCAPObjectName(appName string) string {
+	return appName + "-vcap"
+}
+
+// ConfigChecksum returns the checksum of data of ConfigMaps and Secrets generated for the app
+// keys are sorted so the checksum changes only if data changes
+func ConfigChecksum(data ...map[string]string) string {
+	hash := sha256.New()
+	for _, values := range data {
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			fmt.Fprintf(hash, "%q=%q\n", key, values[key])
+		}
+		// separates data of objects so moving a variable between them changes the checksum
+		fmt.Fprintln(hash, "---")
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+// ReadEnvFile reads variables from the file in the .env format
+// empty lines and lines starting with '#' are skipped, values may be quoted and prefixed with 'export'
+func ReadEnvFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	envs := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid line %d in %s, expected format KEY=VALUE", lineNumber, path)
+		}
+
+		value = strings.TrimSpace(value)
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		} else if len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+			value = value[1 : len(value)-1]
+		}
+
+		envs[key] = value
+	}
+
+	return envs, scanner.Err()
+}
+
+// ApplyEnvConfigMap creates or updates the ConfigMap with variables of the app owned by the app deployment
+func ApplyEnvConfigMap(ctx context.Context, client rootlessdynamic.Interface, owner *appsv1.Deployment, data map[string]string) error {
+	_, err := applyObject(ctx, client, BuildEnvConfigMap(owner, data))
+	return err
+}
+
+// BuildEnvConfigMap returns the ConfigMap with variables of the app without applying it
+func BuildEnvConfigMap(owner *appsv1.Deployment, data map[string]string) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: buildEnvObjectMeta(owner, EnvObjectName(owner.GetName())),
+		Data:       data,
+	}
+}
+
+// ApplyEnvSecret creates or updates the Secret with variables of the app owned by the app deployment
+// values are set in data instead of stringData so removed variables are pruned by the next apply
+func ApplyEnvSecret(ctx context.Context, client rootlessdynamic.Interface, owner *appsv1.Deployment, data map[string]string) error {
+	_, err := applyObject(ctx, client, BuildEnvSecret(owner, data))
+	return err
+}
+
+// BuildEnvSecret returns the Secret with variables of the app without applying it
+func BuildEnvSecret(owner *appsv1.Deployment, data map[string]string) *v1.Secret {
+	secretData := map[string][]byte{}
+	for key, value := range data {
+		secretData[key] = []byte(value)
+	}
+
+	return &v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: buildEnvObjectMeta(owner, EnvObjectName(owner.GetName())),
+		Data:       secretData,
+	}
+}
+
+// ApplyVCAPSecret creates or updates the Secret with the VCAP_SERVICES variable owned by the app deployment
+func ApplyVCAPSecret(ctx context.Context, client rootlessdynamic.Interface, owner *appsv1.Deployment, vcapServices string) error {
+	_, err := applyObject(ctx, client, BuildVCAPSecret(owner, vcapServices))
+	return err
+}
+
+// BuildVCAPSecret returns the Secret with the VCAP_SERVICES variable without applying it
+func BuildVCAPSecret(owner *appsv1.Deployment, vcapServices string) *v1.Secret {
+	return &v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: buildEnvObjectMeta(owner, VCAPObjectName(owner.GetName())),
+		Data: map[string][]byte{
+			"VCAP_SERVICES": []byte(vcapServices),
+		},
+	}
+}
+
+// buildEnvObjectMeta sets the owner reference only if the owner is stored in the cluster
+// the owner has no UID if it's built for the dry run
+func buildEnvObjectMeta(owner *appsv1.Deployment, name string) metav1.ObjectMeta {
+	objectMeta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: owner.GetNamespace(),
+		Labels: map[string]string{
+			"app.kubernetes.io/name":       owner.GetName(),
+			"app.kubernetes.io/created-by": "kyma-cli",
+		},
+	}
+	if owner.GetUID() != "" {
+		objectMeta.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(owner, appsv1.SchemeGroupVersion.WithKind("Deployment")),
+		}
+	}
+
+	return objectMeta
+}
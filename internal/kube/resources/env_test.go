@@ -0,0 +1,154 @@
+package resources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func TestReadEnvFile(t *testing.T) {
+	t.Run("read env file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".env")
+		err := os.WriteFile(path, []byte(`# database
+DB_HOST=postgres.default.svc
+export DB_USER = admin
+DB_PASSWORD="p@ss=word"
+GREETING='hello world'
+
+EMPTY=
+`), 0600)
+		require.NoError(t, err)
+
+		envs, err := ReadEnvFile(path)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"DB_HOST":     "postgres.default.svc",
+			"DB_USER":     "admin",
+			"DB_PASSWORD": "p@ss=word",
+			"GREETING":    "hello world",
+			"EMPTY":       "",
+		}, envs)
+	})
+
+	t.Run("invalid line", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".env")
+		err := os.WriteFile(path, []byte("KEY=VALUE\nINVALID\n"), 0600)
+		require.NoError(t, err)
+
+		_, err = ReadEnvFile(path)
+		require.ErrorContains(t, err, "invalid line 2")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := ReadEnvFile(filepath.Join(t.TempDir(), ".env"))
+		require.Error(t, err)
+	})
+}
+
+func TestApplyEnvConfigMap(t *testing.T) {
+	rootlessdynamic := &rootlessdynamicMock{}
+
+	err := ApplyEnvConfigMap(context.Background(), rootlessdynamic, fixOwnerDeployment(), map[string]string{"KEY": "VALUE"})
+	require.NoError(t, err)
+	require.Len(t, rootlessdynamic.appliedObjects, 1)
+
+	configMap := corev1.ConfigMap{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(rootlessdynamic.appliedObjects[0].Object, &configMap)
+	require.NoError(t, err)
+	require.Equal(t, "ConfigMap", configMap.Kind)
+	require.Equal(t, "app-env", configMap.GetName())
+	require.Equal(t, "default", configMap.GetNamespace())
+	require.Equal(t, map[string]string{"KEY": "VALUE"}, configMap.Data)
+	require.Equal(t, fixOwnerReferences(), configMap.OwnerReferences)
+}
+
+func TestApplyEnvSecret(t *testing.T) {
+	rootlessdynamic := &rootlessdynamicMock{}
+
+	err := ApplyEnvSecret(context.Background(), rootlessdynamic, fixOwnerDeployment(), map[string]string{"KEY": "VALUE"})
+	require.NoError(t, err)
+	require.Len(t, rootlessdynamic.appliedObjects, 1)
+
+	secret := corev1.Secret{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(rootlessdynamic.appliedObjects[0].Object, &secret)
+	require.NoError(t, err)
+	require.Equal(t, "Secret", secret.Kind)
+	require.Equal(t, "app-env", secret.GetName())
+	require.Equal(t, map[string][]byte{"KEY": []byte("VALUE")}, secret.Data)
+	require.Equal(t, fixOwnerReferences(), secret.OwnerReferences)
+}
+
+func TestApplyVCAPSecret(t *testing.T) {
+	rootlessdynamic := &rootlessdynamicMock{}
+
+	err := ApplyVCAPSecret(context.Background(), rootlessdynamic, fixOwnerDeployment(), `{"xsuaa":[]}`)
+	require.NoError(t, err)
+	require.Len(t, rootlessdynamic.appliedObjects, 1)
+
+	secret := corev1.Secret{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(rootlessdynamic.appliedObjects[0].Object, &secret)
+	require.NoError(t, err)
+	require.Equal(t, "Secret", secret.Kind)
+	require.Equal(t, "app-vcap", secret.GetName())
+	require.Equal(t, map[string][]byte{"VCAP_SERVICES": []byte(`{"xsuaa":[]}`)}, secret.Data)
+	require.Equal(t, fixOwnerReferences(), secret.OwnerReferences)
+}
+
+func TestBuildEnvConfigMap(t *testing.T) {
+	t.Run("owner not stored in the cluster", func(t *testing.T) {
+		owner := fixOwnerDeployment()
+		owner.SetUID("")
+
+		configMap := BuildEnvConfigMap(owner, map[string]string{"KEY": "VALUE"})
+		require.Equal(t, "app-env", configMap.GetName())
+		require.Empty(t, configMap.OwnerReferences)
+	})
+}
+
+func TestConfigChecksum(t *testing.T) {
+	checksum := ConfigChecksum(map[string]string{"A": "1", "B": "2"}, map[string]string{"C": "3"})
+
+	t.Run("same data", func(t *testing.T) {
+		require.Equal(t, checksum, ConfigChecksum(map[string]string{"B": "2", "A": "1"}, map[string]string{"C": "3"}))
+	})
+
+	t.Run("changed value", func(t *testing.T) {
+		require.NotEqual(t, checksum, ConfigChecksum(map[string]string{"A": "1", "B": "3"}, map[string]string{"C": "3"}))
+	})
+
+	t.Run("variable moved to another object", func(t *testing.T) {
+		require.NotEqual(t, checksum, ConfigChecksum(map[string]string{"A": "1"}, map[string]string{"B": "2", "C": "3"}))
+	})
+}
+
+func fixOwnerDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "default",
+			UID:       types.UID("app-uid"),
+		},
+	}
+}
+
+func fixOwnerReferences() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion:         "apps/v1",
+			Kind:               "Deployment",
+			Name:               "app",
+			UID:                types.UID("app-uid"),
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		},
+	}
+}
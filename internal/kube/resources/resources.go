@@ -2,7 +2,11 @@ package resources
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/kyma-project/api-gateway/apis/gateway/v2alpha1"
 	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
@@ -13,11 +17,11 @@ import (
 	v1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/ptr"
 )
 
@@ -84,72 +88,258 @@ func CreateClusterRoleBinding(ctx context.Context, client kube.Client, name, nam
 	return nil
 }
 
-func CreateDeployment(ctx context.Context, client kube.Client, name, namespace, image, imagePullSecret string, injectIstio types.NullableBool) error {
+type DeploymentOpts struct {
+	Name            string
+	Namespace       string
+	Image           string
+	ImagePullSecret string
+	InjectIstio     types.NullableBool
+	Replicas        int32
+	ContainerPort   types.NullableInt64
+	Resources       v1.ResourceRequirements
+	LivenessProbe   types.Probe
+	ReadinessProbe  types.Probe
+	Command         []string
+	Args            []string
+	// Env contains variables set directly in the app container
+	Env map[string]string
+	// EnvFromConfigMaps and EnvFromSecrets contain names of resources with variables for the app container
+	EnvFromConfigMaps []string
+	EnvFromSecrets    []string
+	ConfigMapMounts   []Mount
+	SecretMounts      []Mount
+	// ConfigChecksum is set in the pod template annotation so pods are restarted when generated configuration changes
+	ConfigChecksum string
+}
+
+// ConfigChecksumAnnotation is the pod template annotation with the checksum of configuration generated for the app
+const ConfigChecksumAnnotation = "kyma-project.io/config-checksum"
+
+// ApplyDeployment creates or updates the app deployment and returns it as stored in the cluster
+func ApplyDeployment(ctx context.Context, client rootlessdynamic.Interface, opts DeploymentOpts) (*appsv1.Deployment, error) {
+	uDeployment, err := applyObject(ctx, client, BuildDeployment(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := &appsv1.Deployment{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(uDeployment.Object, deployment)
+	return deployment, err
+}
+
+// BuildDeployment returns the app deployment without applying it
+func BuildDeployment(opts DeploymentOpts) *appsv1.Deployment {
+	container := v1.Container{
+		Name:           opts.Name,
+		Image:          opts.Image,
+		Command:        opts.Command,
+		Args:           opts.Args,
+		Resources:      opts.Resources,
+		LivenessProbe:  buildProbe(opts.LivenessProbe, opts.ContainerPort),
+		ReadinessProbe: buildProbe(opts.ReadinessProbe, opts.ContainerPort),
+		Env:            buildEnv(opts.Env),
+		EnvFrom:        buildEnvFrom(opts.EnvFromConfigMaps, opts.EnvFromSecrets),
+	}
+	if opts.ContainerPort.Value != nil {
+		container.Ports = []v1.ContainerPort{
+			{
+				ContainerPort: int32(*opts.ContainerPort.Value),
+			},
+		}
+	}
+
 	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name: name,
+			Name:      opts.Name,
+			Namespace: opts.Namespace,
 			Labels: map[string]string{
-				"app.kubernetes.io/name":       name,
+				"app.kubernetes.io/name":       opts.Name,
 				"app.kubernetes.io/created-by": "kyma-cli",
 			},
 		},
 		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(opts.Replicas),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{
-					"app": name,
+					"app": opts.Name,
 				},
 			},
 			Template: v1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: name,
+					Name: opts.Name,
 					Labels: map[string]string{
-						"app": name,
+						"app": opts.Name,
 					},
 				},
 				Spec: v1.PodSpec{
-					Containers: []v1.Container{
-						{
-							Ports: []v1.ContainerPort{
-								{
-									ContainerPort: 80,
-								},
-							},
-							Name:  name,
-							Image: image,
-							Resources: v1.ResourceRequirements{
-								Requests: v1.ResourceList{
-									v1.ResourceMemory: resource.MustParse("64Mi"),
-									v1.ResourceCPU:    resource.MustParse("50m"),
-								},
-								Limits: v1.ResourceList{
-									v1.ResourceMemory: resource.MustParse("128Mi"),
-									v1.ResourceCPU:    resource.MustParse("100m"),
-								},
-							},
-						},
-					},
+					Containers: []v1.Container{container},
 				},
 			},
 		},
 	}
-	if injectIstio.Value != nil {
-		deployment.Spec.Template.ObjectMeta.Labels["sidecar.istio.io/inject"] = injectIstio.String()
+	addMounts(&deployment.Spec.Template.Spec, opts.ConfigMapMounts, opts.SecretMounts)
+	if opts.ConfigChecksum != "" {
+		deployment.Spec.Template.ObjectMeta.Annotations = map[string]string{
+			ConfigChecksumAnnotation: opts.ConfigChecksum,
+		}
+	}
+	if opts.InjectIstio.Value != nil {
+		deployment.Spec.Template.ObjectMeta.Labels["sidecar.istio.io/inject"] = opts.InjectIstio.String()
 	}
 
-	if imagePullSecret != "" {
+	if opts.ImagePullSecret != "" {
 		deployment.Spec.Template.Spec.ImagePullSecrets = []v1.LocalObjectReference{
 			{
-				Name: imagePullSecret,
+				Name: opts.ImagePullSecret,
+			},
+		}
+	}
+
+	return deployment
+}
+
+// buildEnv returns variables sorted by name to keep the deployment stable
+func buildEnv(env map[string]string) []v1.EnvVar {
+	envVars := []v1.EnvVar{}
+	for _, name := range slices.Sorted(maps.Keys(env)) {
+		envVars = append(envVars, v1.EnvVar{
+			Name:  name,
+			Value: env[name],
+		})
+	}
+
+	if len(envVars) == 0 {
+		return nil
+	}
+	return envVars
+}
+
+func buildEnvFrom(configMaps, secrets []string) []v1.EnvFromSource {
+	envFrom := []v1.EnvFromSource{}
+	for _, configMap := range configMaps {
+		envFrom = append(envFrom, v1.EnvFromSource{
+			ConfigMapRef: &v1.ConfigMapEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: configMap},
+			},
+		})
+	}
+	for _, secret := range secrets {
+		envFrom = append(envFrom, v1.EnvFromSource{
+			SecretRef: &v1.SecretEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: secret},
+			},
+		})
+	}
+
+	if len(envFrom) == 0 {
+		return nil
+	}
+	return envFrom
+}
+
+// addMounts adds volumes with ConfigMaps and Secrets to the pod and mounts them in the app container
+// every ConfigMap and Secret gets a single volume even if it's mounted under many paths
+func addMounts(podSpec *v1.PodSpec, configMapMounts, secretMounts []Mount) {
+	for _, mount := range configMapMounts {
+		addMount(podSpec, volumeName("configmap", mount.Name), mount.Path, v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: mount.Name},
+			},
+		})
+	}
+	for _, mount := range secretMounts {
+		addMount(podSpec, volumeName("secret", mount.Name), mount.Path, v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: mount.Name,
+			},
+		})
+	}
+}
+
+func addMount(podSpec *v1.PodSpec, name, path string, source v1.VolumeSource) {
+	if !slices.ContainsFunc(podSpec.Volumes, func(volume v1.Volume) bool {
+		return volume.Name == name
+	}) {
+		podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+			Name:         name,
+			VolumeSource: source,
+		})
+	}
+
+	container := &podSpec.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, v1.VolumeMount{
+		Name:      name,
+		MountPath: path,
+		ReadOnly:  true,
+	})
+}
+
+// volumeName returns the DNS-1123 label name of the volume with the object
+// names with dots or longer than 63 characters are truncated and suffixed with a hash of the object name to stay unique
+func volumeName(prefix, objectName string) string {
+	name := prefix + "-" + objectName
+	if len(validation.IsDNS1123Label(name)) == 0 {
+		return name
+	}
+
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(objectName)))[:8]
+	name = strings.ReplaceAll(name, ".", "-")
+	if maxLen := validation.DNS1123LabelMaxLength - len(hash) - 1; len(name) > maxLen {
+		name = name[:maxLen]
+	}
+
+	return strings.TrimRight(name, "-") + "-" + hash
+}
+
+// buildProbe returns nil if the probe is not set
+// the container port is used if the probe has no port
+func buildProbe(probe types.Probe, containerPort types.NullableInt64) *v1.Probe {
+	if !probe.IsSet() {
+		return nil
+	}
+
+	port := probe.Port
+	if port == 0 && containerPort.Value != nil {
+		port = int32(*containerPort.Value)
+	}
+
+	if probe.Protocol == types.TCPProbe {
+		return &v1.Probe{
+			ProbeHandler: v1.ProbeHandler{
+				TCPSocket: &v1.TCPSocketAction{
+					Port: intstr.FromInt32(port),
+				},
 			},
 		}
 	}
 
-	_, err := client.Static().AppsV1().Deployments(namespace).Create(ctx, deployment, metav1.CreateOptions{})
+	return &v1.Probe{
+		ProbeHandler: v1.ProbeHandler{
+			HTTPGet: &v1.HTTPGetAction{
+				Path: probe.Path,
+				Port: intstr.FromInt32(port),
+			},
+		},
+	}
+}
+
+// ApplyService creates or updates the service of the app
+func ApplyService(ctx context.Context, client rootlessdynamic.Interface, name, namespace string, port int32) error {
+	_, err := applyObject(ctx, client, BuildService(name, namespace, port))
 	return err
 }
 
-func CreateService(ctx context.Context, client kube.Client, name, namespace string, port int32) error {
-	service := &v1.Service{
+// BuildService returns the service of the app without applying it
+func BuildService(name, namespace string, port int32) *v1.Service {
+	return &v1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
@@ -170,47 +360,101 @@ func CreateService(ctx context.Context, client kube.Client, name, namespace stri
 			},
 		},
 	}
-	_, err := client.Static().CoreV1().Services(namespace).Create(ctx, service, metav1.CreateOptions{})
+}
+
+// APIRuleOpts describes the APIRule exposing the app
+// the rule exposing all paths without authorization is used if rules are empty
+type APIRuleOpts struct {
+	Name      string
+	Namespace string
+	Domain    string
+	Port      uint32
+	Rules     []v2alpha1.Rule
+}
+
+// ApplyAPIRule creates or updates the APIRule exposing the app
+func ApplyAPIRule(ctx context.Context, client rootlessdynamic.Interface, opts APIRuleOpts) error {
+	_, err := applyObject(ctx, client, BuildAPIRule(opts))
 	return err
 }
 
-func CreateAPIRule(ctx context.Context, client rootlessdynamic.Interface, name, namespace, domain string, port uint32) error {
-	apirule := v2alpha1.APIRule{
+// BuildAPIRule returns the APIRule exposing the app without applying it
+func BuildAPIRule(opts APIRuleOpts) *v2alpha1.APIRule {
+	rules := opts.Rules
+	if len(rules) == 0 {
+		rules = BuildDefaultAPIRuleRules(APIRuleAuth{})
+	}
+
+	return &v2alpha1.APIRule{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "gateway.kyma-project.io/v2alpha1",
 			Kind:       "APIRule",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
+			Name:      opts.Name,
+			Namespace: opts.Namespace,
 			Labels: map[string]string{
-				"app.kubernetes.io/name":       name,
+				"app.kubernetes.io/name":       opts.Name,
 				"app.kubernetes.io/created-by": "kyma-cli",
 			},
 		},
 		Spec: v2alpha1.APIRuleSpec{
 			Hosts: []*v2alpha1.Host{
-				ptr.To(v2alpha1.Host(fmt.Sprintf("%s.%s", name, domain))),
+				ptr.To(v2alpha1.Host(fmt.Sprintf("%s.%s", opts.Name, opts.Domain))),
 			},
 			Gateway: ptr.To(fmt.Sprintf("%s/%s", istio.GatewayNamespace, istio.GatewayName)),
-			Rules: []v2alpha1.Rule{
-				{
-					Path:    "/*",
-					Methods: []v2alpha1.HttpMethod{"GET", "POST", "PUT", "DELETE", "PATCH"},
-					NoAuth:  ptr.To(true),
-				},
-			},
+			Rules:   rules,
 			Service: &v2alpha1.Service{
-				Name:      ptr.To(name),
-				Namespace: ptr.To(namespace),
-				Port:      &port,
+				Name:      ptr.To(opts.Name),
+				Namespace: ptr.To(opts.Namespace),
+				Port:      &opts.Port,
 			},
 		},
 	}
+}
 
-	uAPIRule, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&apirule)
+// ToUnstructured converts the built object to the form sent to the cluster
+// status owned by controllers and empty fields, like the not omitted APIRule noAuth, are removed
+func ToUnstructured(obj interface{}) (*unstructured.Unstructured, error) {
+	uObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	unstructured.RemoveNestedField(uObj, "status")
+	removeNullFields(uObj)
+
+	return &unstructured.Unstructured{Object: uObj}, nil
+}
+
+func removeNullFields(value interface{}) {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for key, fieldValue := range typedValue {
+			if fieldValue == nil {
+				delete(typedValue, key)
+				continue
+			}
+			removeNullFields(fieldValue)
+		}
+	case []interface{}:
+		for _, item := range typedValue {
+			removeNullFields(item)
+		}
+	}
+}
+
+// applyObject applies the object using server-side apply and returns it as stored in the cluster
+func applyObject(ctx context.Context, client rootlessdynamic.Interface, obj interface{}) (*unstructured.Unstructured, error) {
+	resource, err := ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.Apply(ctx, resource)
+	if err != nil {
+		return nil, err
 	}
-	return client.Apply(ctx, &unstructured.Unstructured{Object: uAPIRule})
+
+	return client.Get(ctx, resource)
 }
@@ -5,16 +5,20 @@ import (
 	"fmt"
 	"github.com/kyma-project/cli.v3/internal/kube/istio"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"strings"
 	"testing"
 
 	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
 	kube_fake "github.com/kyma-project/cli.v3/internal/kube/fake"
 	"github.com/stretchr/testify/require"
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	k8s_fake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
 )
 
 func Test_CreateClusterRoleBinding(t *testing.T) {
@@ -92,131 +96,273 @@ func Test_CreateClusterRoleBinding(t *testing.T) {
 	}
 }
 
-func Test_CreateDeployment(t *testing.T) {
-	t.Parallel()
-	trueValue := true
-	tests := []struct {
-		name           string
-		deploymentName string
-		namespace      string
-		image          string
-		istioInject    *bool
-		wantErr        bool
-	}{
-		{
-			name:           "create deployment",
-			deploymentName: "deployment",
-			namespace:      "default",
-			image:          "nginx",
-			wantErr:        false,
-		},
-		{
-			name:           "create deployment with istio label",
-			deploymentName: "deployment",
-			namespace:      "default",
-			image:          "nginx",
-			istioInject:    &trueValue,
-			wantErr:        false,
-		},
-		{
-			name:           "do not allow creating existing deployment",
-			deploymentName: "existing",
-			namespace:      "default",
-			image:          "nginx",
-			wantErr:        true,
-		},
-	}
+func Test_ApplyDeployment(t *testing.T) {
+	t.Run("apply deployment", func(t *testing.T) {
+		ctx := context.Background()
+		rootlessdynamic := &rootlessdynamicMock{}
+		istioInject := true
 
-	ctx := context.Background()
-	for _, tt := range tests {
-		deploymentName := tt.deploymentName
-		namespace := tt.namespace
-		image := tt.image
-		istioInject := tt.istioInject
-		wantErr := tt.wantErr
+		deployment, err := ApplyDeployment(ctx, rootlessdynamic, DeploymentOpts{
+			Name:        "deployment",
+			Namespace:   "default",
+			Image:       "nginx",
+			Replicas:    1,
+			InjectIstio: types.NullableBool{Value: &istioInject},
+		})
 
-		t.Run(tt.name, func(t *testing.T) {
-			existingDeployment := appsv1.Deployment{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "existing",
-					Namespace: "default",
+		require.NoError(t, err)
+		require.Equal(t, "deployment", deployment.GetName())
+		require.Equal(t, "true", deployment.Spec.Template.Labels["sidecar.istio.io/inject"])
+		require.Equal(t, 1, len(rootlessdynamic.appliedObjects))
+		require.Equal(t, "apps/v1", rootlessdynamic.appliedObjects[0].GetAPIVersion())
+		require.Equal(t, "Deployment", rootlessdynamic.appliedObjects[0].GetKind())
+		require.Equal(t, "default", rootlessdynamic.appliedObjects[0].GetNamespace())
+		require.NotContains(t, rootlessdynamic.appliedObjects[0].Object, "status")
+	})
+
+	t.Run("apply error", func(t *testing.T) {
+		ctx := context.Background()
+		rootlessdynamic := &rootlessdynamicMock{
+			returnErr: fmt.Errorf("apply error"),
+		}
+
+		_, err := ApplyDeployment(ctx, rootlessdynamic, DeploymentOpts{
+			Name:      "deployment",
+			Namespace: "default",
+			Image:     "nginx",
+		})
+		require.ErrorContains(t, err, "apply error")
+	})
+}
+
+func Test_BuildDeployment(t *testing.T) {
+	t.Run("build deployment with workload spec", func(t *testing.T) {
+		port := int64(8080)
+		resources := corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("512Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		}
+
+		deployment := BuildDeployment(DeploymentOpts{
+			Name:            "app",
+			Namespace:       "default",
+			Image:           "app:1.0.0",
+			ImagePullSecret: "registry-secret",
+			Replicas:        3,
+			ContainerPort:   types.NullableInt64{Value: &port},
+			Resources:       resources,
+			LivenessProbe:   types.Probe{Protocol: types.HTTPProbe, Path: "/healthz"},
+			ReadinessProbe:  types.Probe{Protocol: types.TCPProbe, Port: 9090},
+			Command:         []string{"java"},
+			Args:            []string{"-jar", "app.jar"},
+		})
+
+		require.Equal(t, ptr.To(int32(3)), deployment.Spec.Replicas)
+		require.Equal(t, []corev1.LocalObjectReference{{Name: "registry-secret"}}, deployment.Spec.Template.Spec.ImagePullSecrets)
+		require.Equal(t, corev1.Container{
+			Name:    "app",
+			Image:   "app:1.0.0",
+			Command: []string{"java"},
+			Args:    []string{"-jar", "app.jar"},
+			Ports: []corev1.ContainerPort{
+				{ContainerPort: 8080},
+			},
+			Resources: resources,
+			LivenessProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					HTTPGet: &corev1.HTTPGetAction{
+						Path: "/healthz",
+						Port: intstr.FromInt32(8080),
+					},
 				},
-			}
-			staticClient := k8s_fake.NewSimpleClientset(
-				&existingDeployment,
-			)
-			kubeClient := &kube_fake.FakeKubeClient{
-				TestKubernetesInterface: staticClient,
-			}
+			},
+			ReadinessProbe: &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					TCPSocket: &corev1.TCPSocketAction{
+						Port: intstr.FromInt32(9090),
+					},
+				},
+			},
+		}, deployment.Spec.Template.Spec.Containers[0])
+	})
 
-			err := CreateDeployment(ctx, kubeClient, deploymentName, namespace, image, "", types.NullableBool{Value: istioInject})
-			if wantErr {
-				require.Error(t, err)
-			} else {
-				require.NoError(t, err)
-			}
+	t.Run("build deployment without container port", func(t *testing.T) {
+		deployment := BuildDeployment(DeploymentOpts{
+			Name:     "app",
+			Image:    "app:1.0.0",
+			Replicas: 1,
 		})
-	}
+
+		container := deployment.Spec.Template.Spec.Containers[0]
+		require.Empty(t, container.Ports)
+		require.Nil(t, container.LivenessProbe)
+		require.Nil(t, container.ReadinessProbe)
+	})
+
+	t.Run("build deployment with env and mounts", func(t *testing.T) {
+		deployment := BuildDeployment(DeploymentOpts{
+			Name:              "app",
+			Image:             "app:1.0.0",
+			Replicas:          1,
+			Env:               map[string]string{"LOG_LEVEL": "debug", "API_URL": "http://api"},
+			EnvFromConfigMaps: []string{"app-env"},
+			EnvFromSecrets:    []string{"app-credentials"},
+			ConfigMapMounts:   []Mount{{Name: "app-config", Path: "/etc/config"}},
+			SecretMounts:      []Mount{{Name: "app-certs", Path: "/etc/certs"}},
+		})
+
+		podSpec := deployment.Spec.Template.Spec
+		require.Equal(t, []corev1.EnvVar{
+			{Name: "API_URL", Value: "http://api"},
+			{Name: "LOG_LEVEL", Value: "debug"},
+		}, podSpec.Containers[0].Env)
+		require.Equal(t, []corev1.EnvFromSource{
+			{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-env"}}},
+			{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-credentials"}}},
+		}, podSpec.Containers[0].EnvFrom)
+		require.Equal(t, []corev1.Volume{
+			{
+				Name: "configmap-app-config",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}},
+				},
+			},
+			{
+				Name: "secret-app-certs",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: "app-certs"},
+				},
+			},
+		}, podSpec.Volumes)
+		require.Equal(t, []corev1.VolumeMount{
+			{Name: "configmap-app-config", MountPath: "/etc/config", ReadOnly: true},
+			{Name: "secret-app-certs", MountPath: "/etc/certs", ReadOnly: true},
+		}, podSpec.Containers[0].VolumeMounts)
+	})
+
+	t.Run("restart pods when generated configuration changes", func(t *testing.T) {
+		opts := DeploymentOpts{
+			Name:              "app",
+			Image:             "app:1.0.0",
+			Replicas:          1,
+			EnvFromConfigMaps: []string{"app-env"},
+			ConfigChecksum:    ConfigChecksum(map[string]string{"LOG_LEVEL": "info"}),
+		}
+		deployment := BuildDeployment(opts)
+		require.Equal(t, opts.ConfigChecksum, deployment.Spec.Template.Annotations[ConfigChecksumAnnotation])
+
+		opts.ConfigChecksum = ConfigChecksum(map[string]string{"LOG_LEVEL": "debug"})
+		changedDeployment := BuildDeployment(opts)
+		require.NotEqual(t, deployment.Spec.Template, changedDeployment.Spec.Template)
+	})
+
+	t.Run("build deployment with object mounted many times", func(t *testing.T) {
+		deployment := BuildDeployment(DeploymentOpts{
+			Name:     "app",
+			Image:    "app:1.0.0",
+			Replicas: 1,
+			ConfigMapMounts: []Mount{
+				{Name: "app-config", Path: "/etc/config"},
+				{Name: "app-config", Path: "/opt/config"},
+			},
+		})
+
+		podSpec := deployment.Spec.Template.Spec
+		require.Len(t, podSpec.Volumes, 1)
+		require.Equal(t, "configmap-app-config", podSpec.Volumes[0].Name)
+		require.Equal(t, []corev1.VolumeMount{
+			{Name: "configmap-app-config", MountPath: "/etc/config", ReadOnly: true},
+			{Name: "configmap-app-config", MountPath: "/opt/config", ReadOnly: true},
+		}, podSpec.Containers[0].VolumeMounts)
+	})
 }
 
-func Test_CreateService(t *testing.T) {
-	t.Parallel()
+func Test_volumeName(t *testing.T) {
 	tests := []struct {
-		name        string
-		serviceName string
-		namespace   string
-		port        int32
-		wantErr     bool
+		name       string
+		objectName string
+		want       string
 	}{
 		{
-			name:        "create service",
-			serviceName: "service",
-			namespace:   "default",
-			port:        80,
-			wantErr:     false,
+			name:       "valid name",
+			objectName: "app-config",
+			want:       "configmap-app-config",
 		},
 		{
-			name:        "do not allow creating existing service",
-			serviceName: "existing",
-			namespace:   "default",
-			port:        80,
-			wantErr:     true,
+			name:       "name with dots",
+			objectName: "app.config",
+			want:       "configmap-app-config-9ec9d39f",
+		},
+		{
+			name:       "too long name",
+			objectName: strings.Repeat("a", 60),
+			want:       "configmap-" + strings.Repeat("a", 44) + "-11ee3912",
 		},
 	}
-	ctx := context.Background()
 	for _, tt := range tests {
-		serviceName := tt.serviceName
-		namespace := tt.namespace
-		port := tt.port
-		wantErr := tt.wantErr
-
 		t.Run(tt.name, func(t *testing.T) {
-			existingService := corev1.Service{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "existing",
-					Namespace: "default",
-				},
-			}
-			staticClient := k8s_fake.NewSimpleClientset(
-				&existingService,
-			)
-			kubeClient := &kube_fake.FakeKubeClient{
-				TestKubernetesInterface: staticClient,
-			}
-
-			err := CreateService(ctx, kubeClient, serviceName, namespace, port)
-			if wantErr {
-				require.Error(t, err)
-			} else {
-				require.NoError(t, err)
-			}
+			got := volumeName("configmap", tt.objectName)
+			require.Equal(t, tt.want, got)
+			require.Empty(t, validation.IsDNS1123Label(got))
 		})
 	}
 
+	require.NotEqual(t, volumeName("configmap", "app.config"), volumeName("configmap", "app-config"))
 }
 
-func Test_CreateAPIRule(t *testing.T) {
-	t.Run("create apiRule", func(t *testing.T) {
+func Test_ApplyService(t *testing.T) {
+	t.Run("apply service", func(t *testing.T) {
+		ctx := context.Background()
+		rootlessdynamic := &rootlessdynamicMock{}
+
+		err := ApplyService(ctx, rootlessdynamic, "service", "default", 8080)
+
+		require.NoError(t, err)
+		require.Equal(t, 1, len(rootlessdynamic.appliedObjects))
+		require.Equal(t, unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"metadata": map[string]interface{}{
+					"name":      "service",
+					"namespace": "default",
+					"labels": map[string]interface{}{
+						"app.kubernetes.io/name":       "service",
+						"app.kubernetes.io/created-by": "kyma-cli",
+					},
+				},
+				"spec": map[string]interface{}{
+					"selector": map[string]interface{}{
+						"app": "service",
+					},
+					"ports": []interface{}{
+						map[string]interface{}{
+							"port":       int64(8080),
+							"targetPort": int64(8080),
+						},
+					},
+				},
+			},
+		}, rootlessdynamic.appliedObjects[0])
+	})
+
+	t.Run("apply error", func(t *testing.T) {
+		ctx := context.Background()
+		rootlessdynamic := &rootlessdynamicMock{
+			returnErr: fmt.Errorf("apply error"),
+		}
+
+		err := ApplyService(ctx, rootlessdynamic, "service", "default", 8080)
+		require.ErrorContains(t, err, "apply error")
+	})
+}
+
+func Test_ApplyAPIRule(t *testing.T) {
+	t.Run("apply apiRule", func(t *testing.T) {
 		ctx := context.Background()
 		rootlessdynamic := &rootlessdynamicMock{}
 		apiRuleName := "apiRule"
@@ -224,12 +370,51 @@ func Test_CreateAPIRule(t *testing.T) {
 		domain := "example.com"
 		port := uint32(80)
 
-		err := CreateAPIRule(ctx, rootlessdynamic, apiRuleName, namespace, domain, port)
+		err := ApplyAPIRule(ctx, rootlessdynamic, APIRuleOpts{
+			Name:      apiRuleName,
+			Namespace: namespace,
+			Domain:    domain,
+			Port:      port,
+		})
 
 		require.NoError(t, err)
 		require.Equal(t, 1, len(rootlessdynamic.appliedObjects))
 		require.Equal(t, fixAPIRule(apiRuleName, namespace, domain, port), rootlessdynamic.appliedObjects[0])
 	})
+	t.Run("apply apiRule with jwt rules", func(t *testing.T) {
+		ctx := context.Background()
+		rootlessdynamic := &rootlessdynamicMock{}
+
+		err := ApplyAPIRule(ctx, rootlessdynamic, APIRuleOpts{
+			Name:      "apiRule",
+			Namespace: "default",
+			Domain:    "example.com",
+			Port:      80,
+			Rules: BuildDefaultAPIRuleRules(APIRuleAuth{
+				JwtIssuer:  "https://issuer.example.com",
+				JwtJwksURI: "https://issuer.example.com/keys",
+			}),
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, 1, len(rootlessdynamic.appliedObjects))
+		expectedAPIRule := fixAPIRule("apiRule", "default", "example.com", 80)
+		expectedAPIRule.Object["spec"].(map[string]interface{})["rules"] = []interface{}{
+			map[string]interface{}{
+				"path":    "/*",
+				"methods": []interface{}{"GET", "POST", "PUT", "DELETE", "PATCH"},
+				"jwt": map[string]interface{}{
+					"authentications": []interface{}{
+						map[string]interface{}{
+							"issuer":  "https://issuer.example.com",
+							"jwksUri": "https://issuer.example.com/keys",
+						},
+					},
+				},
+			},
+		}
+		require.Equal(t, expectedAPIRule, rootlessdynamic.appliedObjects[0])
+	})
 	t.Run("do not allow creating existing apiRule", func(t *testing.T) {
 		ctx := context.Background()
 		rootlessdynamic := &rootlessdynamicMock{
@@ -239,11 +424,42 @@ func Test_CreateAPIRule(t *testing.T) {
 		namespace := "default"
 		domain := "example.com"
 		port := uint32(80)
-		err := CreateAPIRule(ctx, rootlessdynamic, apiRuleName, namespace, domain, port)
+		err := ApplyAPIRule(ctx, rootlessdynamic, APIRuleOpts{
+			Name:      apiRuleName,
+			Namespace: namespace,
+			Domain:    domain,
+			Port:      port,
+		})
 		require.Contains(t, err.Error(), "already exists")
 	})
 }
 
+func TestToUnstructured(t *testing.T) {
+	obj, err := ToUnstructured(&corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pod",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name": "pod",
+			},
+			"spec": map[string]interface{}{},
+		},
+	}, obj)
+}
+
 func fixAPIRule(apiRuleName, namespace, domain string, port uint32) unstructured.Unstructured {
 	return unstructured.Unstructured{
 		Object: map[string]interface{}{
@@ -256,7 +472,6 @@ func fixAPIRule(apiRuleName, namespace, domain string, port uint32) unstructured
 					"app.kubernetes.io/name":       apiRuleName,
 					"app.kubernetes.io/created-by": "kyma-cli",
 				},
-				"creationTimestamp": nil,
 			},
 			"spec": map[string]interface{}{
 				"hosts": []interface{}{
@@ -276,7 +491,6 @@ func fixAPIRule(apiRuleName, namespace, domain string, port uint32) unstructured
 					"port":      int64(port),
 				},
 			},
-			"status": map[string]interface{}{"lastProcessedTime": interface{}(nil), "state": ""},
 		},
 	}
 }
@@ -299,6 +513,10 @@ func (m *rootlessdynamicMock) Get(_ context.Context, obj *unstructured.Unstructu
 	return obj, m.returnErr
 }
 
+func (m *rootlessdynamicMock) List(_ context.Context, obj *unstructured.Unstructured) (*unstructured.UnstructuredList, error) {
+	return &unstructured.UnstructuredList{}, m.returnErr
+}
+
 func (m *rootlessdynamicMock) Remove(_ context.Context, obj *unstructured.Unstructured) error {
 	return m.returnErr
 }
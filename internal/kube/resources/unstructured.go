@@ -48,6 +48,11 @@ func DecodeYaml(r io.Reader) ([]unstructured.Unstructured, error) {
 			return nil, err
 		}
 
+		if len(obj) == 0 {
+			// skip empty documents
+			continue
+		}
+
 		u := unstructured.Unstructured{Object: obj}
 		if u.GetObjectKind().GroupVersionKind().Kind == "CustomResourceDefinition" {
 			results = append([]unstructured.Unstructured{u}, results...)
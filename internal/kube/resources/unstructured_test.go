@@ -95,4 +95,11 @@ func Test_decodeYaml(t *testing.T) {
 			t.Errorf("decodeYaml() got = %v, want %v", err, nil)
 		}
 	})
+	t.Run("skip empty documents", func(t *testing.T) {
+		yaml := []byte("---\napiVersion: v1\nkind: Pod\nmetadata:\n  name: test\n---\n---\n")
+		objs, err := DecodeYaml(bytes.NewReader(yaml))
+		require.NoError(t, err)
+		require.Len(t, objs, 1)
+		require.Equal(t, "Pod", objs[0].GetKind())
+	})
 }
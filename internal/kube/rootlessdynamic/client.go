@@ -2,10 +2,11 @@ package rootlessdynamic
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"k8s.io/apimachinery/pkg/api/errors"
 	"strings"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -13,10 +14,18 @@ import (
 	"k8s.io/client-go/dynamic"
 )
 
+// FieldManager is the field manager of all resources applied by the CLI
+// it must stay the same to let the next apply update fields owned by the previous one
+const FieldManager = "cli"
+
+// ErrKindNotRegistered is returned when the kind is missing in the group version registered on the cluster
+var ErrKindNotRegistered = errors.New("not registered on cluster")
+
 type applyFunc func(context.Context, dynamic.ResourceInterface, *unstructured.Unstructured) error
 
 type Interface interface {
 	Get(context.Context, *unstructured.Unstructured) (*unstructured.Unstructured, error)
+	List(context.Context, *unstructured.Unstructured) (*unstructured.UnstructuredList, error)
 	Apply(context.Context, *unstructured.Unstructured) error
 	ApplyMany(context.Context, []unstructured.Unstructured) error
 	Remove(context.Context, *unstructured.Unstructured) error
@@ -35,6 +44,12 @@ func NewClient(dynamic dynamic.Interface, discovery discovery.DiscoveryInterface
 	return NewClientWithApplyFunc(dynamic, discovery, applyResource)
 }
 
+// NewDryRunClient creates client applying resources with the DryRun: All option
+// resources are validated by the API server and admission webhooks but never persisted
+func NewDryRunClient(dynamic dynamic.Interface, discovery discovery.DiscoveryInterface) Interface {
+	return NewClientWithApplyFunc(dynamic, discovery, applyResourceDryRun)
+}
+
 func NewClientWithApplyFunc(dynamic dynamic.Interface, discovery discovery.DiscoveryInterface, applyFunc applyFunc) Interface {
 	return &client{
 		dynamic:   dynamic,
@@ -57,11 +72,32 @@ func (c *client) Get(ctx context.Context, resource *unstructured.Unstructured) (
 	}
 
 	if apiResource.Namespaced {
-		return c.dynamic.Resource(*gvr).Namespace("kyma-system").Get(ctx, resource.GetName(), metav1.GetOptions{})
+		return c.dynamic.Resource(*gvr).Namespace(namespaceOrDefault(resource)).Get(ctx, resource.GetName(), metav1.GetOptions{})
 	}
 	return c.dynamic.Resource(*gvr).Get(ctx, resource.GetName(), metav1.GetOptions{})
 }
 
+// List lists resources of the same kind as the given one
+// namespaced resources are listed from the resource namespace or from all namespaces if it's empty
+func (c *client) List(ctx context.Context, resource *unstructured.Unstructured) (*unstructured.UnstructuredList, error) {
+	group, version := groupVersion(resource.GetAPIVersion())
+	apiResource, err := c.discoverAPIResource(group, version, resource.GetKind())
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover API resource using discovery client: %w", err)
+	}
+
+	gvr := &schema.GroupVersionResource{
+		Group:    group,
+		Version:  version,
+		Resource: apiResource.Name,
+	}
+
+	if apiResource.Namespaced {
+		return c.dynamic.Resource(*gvr).Namespace(resource.GetNamespace()).List(ctx, metav1.ListOptions{})
+	}
+	return c.dynamic.Resource(*gvr).List(ctx, metav1.ListOptions{})
+}
+
 func (c *client) Apply(ctx context.Context, resource *unstructured.Unstructured) error {
 	group, version := groupVersion(resource.GetAPIVersion())
 	apiResource, err := c.discoverAPIResource(group, version, resource.GetKind())
@@ -113,13 +149,13 @@ func (c *client) Remove(ctx context.Context, resource *unstructured.Unstructured
 	}
 
 	if apiResource.Namespaced {
-		err = c.dynamic.Resource(*gvr).Namespace("kyma-system").Delete(ctx, resource.GetName(), metav1.DeleteOptions{})
-		if err != nil && !errors.IsNotFound(err) {
+		err = c.dynamic.Resource(*gvr).Namespace(namespaceOrDefault(resource)).Delete(ctx, resource.GetName(), metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
 			return fmt.Errorf("failed to delete namespaced resource %w", err)
 		}
 	} else {
 		err = c.dynamic.Resource(*gvr).Delete(ctx, resource.GetName(), metav1.DeleteOptions{})
-		if err != nil && !errors.IsNotFound(err) {
+		if err != nil && !apierrors.IsNotFound(err) {
 			return fmt.Errorf("failed to delete cluster-scoped resource %w", err)
 		}
 	}
@@ -140,13 +176,24 @@ func (c *client) RemoveMany(ctx context.Context, objs []unstructured.Unstructure
 func applyResource(ctx context.Context, resourceInterface dynamic.ResourceInterface, resource *unstructured.Unstructured) error {
 	// this function can't be tested because of dynamic.FakeDynamicClient limitations
 	_, err := resourceInterface.Apply(ctx, resource.GetName(), resource, metav1.ApplyOptions{
-		FieldManager: "cli",
+		FieldManager: FieldManager,
 		Force:        true,
 	})
 
 	return err
 }
 
+// applyResourceDryRun validates given object without persisting it
+func applyResourceDryRun(ctx context.Context, resourceInterface dynamic.ResourceInterface, resource *unstructured.Unstructured) error {
+	_, err := resourceInterface.Apply(ctx, resource.GetName(), resource, metav1.ApplyOptions{
+		FieldManager: FieldManager,
+		Force:        true,
+		DryRun:       []string{metav1.DryRunAll},
+	})
+
+	return err
+}
+
 func (c *client) discoverAPIResource(group, version, kind string) (*metav1.APIResource, error) {
 	groupVersion := schema.GroupVersion{Group: group, Version: version}
 
@@ -160,7 +207,15 @@ func (c *client) discoverAPIResource(group, version, kind string) (*metav1.APIRe
 			return &apiResource, nil
 		}
 	}
-	return nil, fmt.Errorf("resource '%s' in group '%s', and version '%s' not registered on cluster", kind, group, version)
+	return nil, fmt.Errorf("resource '%s' in group '%s', and version '%s' %w", kind, group, version, ErrKindNotRegistered)
+}
+
+// namespaceOrDefault returns namespace of the resource or the kyma-system namespace if it's empty
+func namespaceOrDefault(resource *unstructured.Unstructured) string {
+	if resource.GetNamespace() != "" {
+		return resource.GetNamespace()
+	}
+	return "kyma-system"
 }
 
 func groupVersion(version string) (string, string) {
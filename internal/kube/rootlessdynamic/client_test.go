@@ -190,6 +190,18 @@ func Test_Get(t *testing.T) {
 		require.Equal(t, obj, result)
 	})
 
+	t.Run("get namespaced resource from other namespace", func(t *testing.T) {
+		obj, apiResource := fixSecretObjectAndApiResource()
+		obj.SetNamespace("default")
+		ctx := context.Background()
+		dynamic := dynamic_fake.NewSimpleDynamicClient(scheme.Scheme, obj)
+		client := fixRootlessDynamic(dynamic, []*metav1.APIResourceList{apiResource})
+
+		result, err := client.Get(ctx, obj)
+		require.NoError(t, err)
+		require.Equal(t, obj, result)
+	})
+
 	t.Run("get cluster-scoped resource", func(t *testing.T) {
 		obj, apiResource := fixClusterRoleObjectAndApiResource()
 		ctx := context.Background()
@@ -216,6 +228,71 @@ func Test_Get(t *testing.T) {
 	})
 }
 
+func Test_List(t *testing.T) {
+	t.Run("list namespaced resources from all namespaces", func(t *testing.T) {
+		obj, apiResource := fixSecretObjectAndApiResource()
+		ctx := context.Background()
+		dynamic := dynamic_fake.NewSimpleDynamicClient(scheme.Scheme, obj)
+		client := fixRootlessDynamic(dynamic, []*metav1.APIResourceList{apiResource})
+
+		result, err := client.List(ctx, &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Items, 1)
+		require.Equal(t, *obj, result.Items[0])
+	})
+
+	t.Run("list namespaced resources from other namespace", func(t *testing.T) {
+		obj, apiResource := fixSecretObjectAndApiResource()
+		ctx := context.Background()
+		dynamic := dynamic_fake.NewSimpleDynamicClient(scheme.Scheme, obj)
+		client := fixRootlessDynamic(dynamic, []*metav1.APIResourceList{apiResource})
+
+		result, err := client.List(ctx, &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata": map[string]interface{}{
+					"namespace": "default",
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Empty(t, result.Items)
+	})
+
+	t.Run("list cluster-scoped resources", func(t *testing.T) {
+		obj, apiResource := fixClusterRoleObjectAndApiResource()
+		ctx := context.Background()
+		dynamic := dynamic_fake.NewSimpleDynamicClient(scheme.Scheme, obj)
+		client := fixRootlessDynamic(dynamic, []*metav1.APIResourceList{apiResource})
+
+		result, err := client.List(ctx, obj)
+		require.NoError(t, err)
+		require.Len(t, result.Items, 1)
+		require.Equal(t, *obj, result.Items[0])
+	})
+
+	t.Run("list resources error because can't be discovered", func(t *testing.T) {
+		obj, _ := fixSecretObjectAndApiResource()
+		ctx := context.Background()
+		dynamic := dynamic_fake.NewSimpleDynamicClient(scheme.Scheme)
+		client := fixRootlessDynamic(dynamic, []*metav1.APIResourceList{
+			{
+				GroupVersion: "v1",
+			},
+		})
+
+		_, err := client.List(ctx, obj)
+		require.ErrorContains(t, err, "failed to discover API resource using discovery client: resource 'Secret' in group '', and version 'v1' not registered on cluster")
+		require.ErrorIs(t, err, ErrKindNotRegistered)
+	})
+}
+
 func Test_Remove(t *testing.T) {
 	t.Run("remove namespaced resource", func(t *testing.T) {
 		obj, apiResource := fixSecretObjectAndApiResource()
@@ -0,0 +1,235 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/kube"
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"github.com/kyma-project/cli.v3/internal/kube/resources"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// time to wait until finalizers of the default CR are handled by the module manager
+	defaultCRRemovalTimeout  = 2 * time.Minute
+	defaultCRRemovalInterval = 2 * time.Second
+)
+
+// EnableCommunity installs community module by applying resources linked in its ModuleTemplate
+// if version is empty the module must have only one ModuleTemplate in the cluster
+// if defaultCR is true the default module CR from the ModuleTemplate is applied too
+func EnableCommunity(ctx context.Context, client kube.Client, module, version string, defaultCR bool) clierror.Error {
+	moduleTemplate, clierr := findModuleTemplateByVersion(ctx, client.Kyma(), module, version)
+	if clierr != nil {
+		return clierr
+	}
+
+	objs, clierr := fetchModuleResources(ctx, moduleTemplate)
+	if clierr != nil {
+		return clierr
+	}
+
+	err := client.RootlessDynamic().ApplyMany(ctx, objs)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to apply resources of the %s module", module)))
+	}
+
+	if !defaultCR || len(moduleTemplate.Spec.Data.Object) == 0 {
+		return nil
+	}
+
+	err = client.RootlessDynamic().Apply(ctx, getDefaultCR(moduleTemplate))
+	if err != nil {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to apply default CR of the %s module", module)))
+	}
+
+	return nil
+}
+
+// DisableCommunity uninstalls community module by removing its default CR and resources linked in its ModuleTemplate
+// if force is false it fails when there are still resources in the cluster that block the module deletion
+func DisableCommunity(ctx context.Context, client kube.Client, module, version string, force bool) clierror.Error {
+	moduleTemplate, clierr := findModuleTemplateByVersion(ctx, client.Kyma(), module, version)
+	if clierr != nil {
+		return clierr
+	}
+
+	if !force {
+		clierr = checkBlockingResources(ctx, client, module)
+		if clierr != nil {
+			return clierr
+		}
+	}
+
+	if len(moduleTemplate.Spec.Data.Object) != 0 {
+		// default CR must be removed before the module manager to let it clean up module resources
+		clierr = removeDefaultCR(ctx, client, moduleTemplate)
+		if clierr != nil {
+			return clierr
+		}
+	}
+
+	objs, clierr := fetchModuleResources(ctx, moduleTemplate)
+	if clierr != nil {
+		return clierr
+	}
+
+	// namespaces can be shared with other workloads so they are never removed
+	objs = slices.DeleteFunc(objs, func(obj unstructured.Unstructured) bool {
+		return obj.GetKind() == "Namespace"
+	})
+
+	// remove resources in reverse order to delete CRDs at the end
+	slices.Reverse(objs)
+	err := client.RootlessDynamic().RemoveMany(ctx, objs)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to remove resources of the %s module", module)))
+	}
+
+	return nil
+}
+
+func removeDefaultCR(ctx context.Context, client kube.Client, moduleTemplate *kyma.ModuleTemplate) clierror.Error {
+	defaultCR := getDefaultCR(moduleTemplate)
+	err := client.RootlessDynamic().Remove(ctx, defaultCR)
+	if apierrors.IsNotFound(err) {
+		// CRD of the default CR does not exist so there is nothing to remove
+		return nil
+	}
+	if err != nil {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to remove default CR of the %s module", moduleTemplate.Spec.ModuleName)))
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, defaultCRRemovalInterval, defaultCRRemovalTimeout, true, func(ctx context.Context) (bool, error) {
+		_, err := client.RootlessDynamic().Get(ctx, defaultCR)
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+
+		return false, err
+	})
+	if err != nil {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to wait for removal of the %s module default CR", moduleTemplate.Spec.ModuleName),
+			"Check if the default CR is blocked by finalizers"))
+	}
+
+	return nil
+}
+
+// getDefaultCR returns copy of the default CR from the ModuleTemplate
+// the kyma-system namespace is used if the CR has no namespace
+func getDefaultCR(moduleTemplate *kyma.ModuleTemplate) *unstructured.Unstructured {
+	defaultCR := moduleTemplate.Spec.Data.DeepCopy()
+	if defaultCR.GetNamespace() == "" {
+		defaultCR.SetNamespace(kyma.DefaultKymaNamespace)
+	}
+
+	return defaultCR
+}
+
+// look for ModuleTemplate of the module in the given version or the only one if version is empty
+func findModuleTemplateByVersion(ctx context.Context, client kyma.Interface, module, version string) (*kyma.ModuleTemplate, clierror.Error) {
+	moduleTemplates, err := client.ListModuleTemplate(ctx)
+	if err != nil {
+		return nil, clierror.Wrap(err, clierror.New("failed to list available modules from the cluster"))
+	}
+
+	matching := []kyma.ModuleTemplate{}
+	versions := []string{}
+	for _, moduleTemplate := range moduleTemplates.Items {
+		if moduleTemplate.Spec.ModuleName != module {
+			continue
+		}
+
+		versions = append(versions, moduleTemplate.Spec.Version)
+		if version == "" || moduleTemplate.Spec.Version == version {
+			matching = append(matching, moduleTemplate)
+		}
+	}
+
+	if len(versions) == 0 {
+		return nil, clierror.New(fmt.Sprintf("module %s is not available on the cluster", module),
+			"Use the 'kyma alpha modules list' command to see available modules")
+	}
+
+	if len(matching) == 0 {
+		return nil, clierror.New(fmt.Sprintf("version %s is not available for the %s module", version, module),
+			fmt.Sprintf("Use one of the available versions: %s", strings.Join(versions, ", ")))
+	}
+
+	if len(matching) > 1 {
+		return nil, clierror.New(fmt.Sprintf("found more than one version of the %s module", module),
+			fmt.Sprintf("Use the --version flag to choose one of the available versions: %s", strings.Join(versions, ", ")))
+	}
+
+	return &matching[0], nil
+}
+
+// fetchModuleResources downloads and decodes all resources linked in the ModuleTemplate
+func fetchModuleResources(ctx context.Context, moduleTemplate *kyma.ModuleTemplate) ([]unstructured.Unstructured, clierror.Error) {
+	if len(moduleTemplate.Spec.Resources) == 0 {
+		return nil, clierror.New(fmt.Sprintf("module %s has no resources defined in the ModuleTemplate", moduleTemplate.Spec.ModuleName),
+			"Make sure the module is a community module")
+	}
+
+	objs := []unstructured.Unstructured{}
+	for _, resource := range moduleTemplate.Spec.Resources {
+		decoded, err := fetchResources(ctx, resource.Link)
+		if err != nil {
+			return nil, clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to fetch the %s resources of the %s module", resource.Name, moduleTemplate.Spec.ModuleName)))
+		}
+
+		objs = append(objs, decoded...)
+	}
+
+	return objs, nil
+}
+
+// fetchResources decodes resources from the given link
+// supports 'http://', 'https://' and 'file://' links
+func fetchResources(ctx context.Context, link string) ([]unstructured.Unstructured, error) {
+	reader, err := openLink(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return resources.DecodeYaml(reader)
+}
+
+func openLink(ctx context.Context, link string) (io.ReadCloser, error) {
+	if path, ok := strings.CutPrefix(link, "file://"); ok {
+		return os.Open(path)
+	}
+
+	if !strings.HasPrefix(link, "http://") && !strings.HasPrefix(link, "https://") {
+		return nil, fmt.Errorf("unsupported link '%s'", link)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code %d for link '%s'", resp.StatusCode, link)
+	}
+
+	return resp.Body, nil
+}
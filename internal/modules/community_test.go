@@ -0,0 +1,250 @@
+package modules
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kube_fake "github.com/kyma-project/cli.v3/internal/kube/fake"
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"github.com/kyma-project/cli.v3/internal/kube/rootlessdynamic"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	discovery_fake "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/dynamic"
+	dynamic_fake "k8s.io/client-go/dynamic/fake"
+	clientgo_testing "k8s.io/client-go/testing"
+)
+
+const (
+	testCommunityManagerManifest = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: kyma-system
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cap-manager
+  namespace: kyma-system
+`
+	testCommunityCRDManifest = `apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: cap-operator
+  namespace: kyma-system
+`
+)
+
+var (
+	gvrConfigMaps      = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	gvrServiceAccounts = schema.GroupVersionResource{Version: "v1", Resource: "serviceaccounts"}
+	gvrSecrets         = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+)
+
+func TestEnableCommunity(t *testing.T) {
+	t.Run("install community module with default CR", func(t *testing.T) {
+		dynamic, client := fixCommunityKubeClient(t, fixCommunityModuleTemplate(t, "0.0.1"))
+
+		clierr := EnableCommunity(context.Background(), client, "cap", "", true)
+		require.Nil(t, clierr)
+
+		_, err := dynamic.Resource(gvrConfigMaps).Namespace("kyma-system").Get(context.Background(), "cap-manager", metav1.GetOptions{})
+		require.NoError(t, err)
+		_, err = dynamic.Resource(gvrServiceAccounts).Namespace("kyma-system").Get(context.Background(), "cap-operator", metav1.GetOptions{})
+		require.NoError(t, err)
+		_, err = dynamic.Resource(gvrSecrets).Namespace("kyma-system").Get(context.Background(), "cap-default", metav1.GetOptions{})
+		require.NoError(t, err)
+	})
+
+	t.Run("install community module without default CR", func(t *testing.T) {
+		dynamic, client := fixCommunityKubeClient(t, fixCommunityModuleTemplate(t, "0.0.1"))
+
+		clierr := EnableCommunity(context.Background(), client, "cap", "0.0.1", false)
+		require.Nil(t, clierr)
+
+		_, err := dynamic.Resource(gvrConfigMaps).Namespace("kyma-system").Get(context.Background(), "cap-manager", metav1.GetOptions{})
+		require.NoError(t, err)
+		_, err = dynamic.Resource(gvrSecrets).Namespace("kyma-system").Get(context.Background(), "cap-default", metav1.GetOptions{})
+		require.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("more than one version available", func(t *testing.T) {
+		_, client := fixCommunityKubeClient(t,
+			fixCommunityModuleTemplate(t, "0.0.1"),
+			fixCommunityModuleTemplate(t, "0.0.2"),
+		)
+
+		clierr := EnableCommunity(context.Background(), client, "cap", "", false)
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "found more than one version of the cap module")
+		require.Contains(t, clierr.String(), "Use the --version flag to choose one of the available versions: 0.0.1, 0.0.2")
+	})
+
+	t.Run("version not available", func(t *testing.T) {
+		_, client := fixCommunityKubeClient(t, fixCommunityModuleTemplate(t, "0.0.1"))
+
+		clierr := EnableCommunity(context.Background(), client, "cap", "0.0.3", false)
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "version 0.0.3 is not available for the cap module")
+	})
+
+	t.Run("module not available", func(t *testing.T) {
+		_, client := fixCommunityKubeClient(t, fixCommunityModuleTemplate(t, "0.0.1"))
+
+		clierr := EnableCommunity(context.Background(), client, "unknown", "", false)
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "module unknown is not available on the cluster")
+	})
+}
+
+func TestDisableCommunity(t *testing.T) {
+	t.Run("uninstall community module", func(t *testing.T) {
+		dynamic, client := fixCommunityKubeClient(t, fixCommunityModuleTemplate(t, "0.0.1"))
+
+		clierr := EnableCommunity(context.Background(), client, "cap", "", true)
+		require.Nil(t, clierr)
+
+		clierr = DisableCommunity(context.Background(), client, "cap", "", false)
+		require.Nil(t, clierr)
+
+		_, err := dynamic.Resource(gvrConfigMaps).Namespace("kyma-system").Get(context.Background(), "cap-manager", metav1.GetOptions{})
+		require.True(t, apierrors.IsNotFound(err))
+		_, err = dynamic.Resource(gvrServiceAccounts).Namespace("kyma-system").Get(context.Background(), "cap-operator", metav1.GetOptions{})
+		require.True(t, apierrors.IsNotFound(err))
+		_, err = dynamic.Resource(gvrSecrets).Namespace("kyma-system").Get(context.Background(), "cap-default", metav1.GetOptions{})
+		require.True(t, apierrors.IsNotFound(err))
+
+		// namespace is never removed
+		_, err = dynamic.Resource(schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}).Get(context.Background(), "kyma-system", metav1.GetOptions{})
+		require.NoError(t, err)
+	})
+}
+
+func Test_fetchResources(t *testing.T) {
+	t.Run("fetch resources from http link", func(t *testing.T) {
+		server := fixManifestServer(t)
+
+		objs, err := fetchResources(context.Background(), server.URL+"/manager.yaml")
+		require.NoError(t, err)
+		require.Len(t, objs, 2)
+		require.Equal(t, "cap-manager", objs[1].GetName())
+	})
+
+	t.Run("fetch resources from file link", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "crd.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(testCommunityCRDManifest), 0600))
+
+		objs, err := fetchResources(context.Background(), "file://"+path)
+		require.NoError(t, err)
+		require.Len(t, objs, 1)
+		require.Equal(t, "cap-operator", objs[0].GetName())
+	})
+
+	t.Run("unexpected status code", func(t *testing.T) {
+		server := fixManifestServer(t)
+
+		_, err := fetchResources(context.Background(), server.URL+"/missing.yaml")
+		require.ErrorContains(t, err, "unexpected status code 404")
+	})
+
+	t.Run("unsupported link", func(t *testing.T) {
+		_, err := fetchResources(context.Background(), "oci://registry/image")
+		require.ErrorContains(t, err, "unsupported link 'oci://registry/image'")
+	})
+}
+
+func fixManifestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/manager.yaml", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(testCommunityManagerManifest))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func fixCommunityModuleTemplate(t *testing.T, version string) *unstructured.Unstructured {
+	server := fixManifestServer(t)
+	crdPath := filepath.Join(t.TempDir(), "crd.yaml")
+	require.NoError(t, os.WriteFile(crdPath, []byte(testCommunityCRDManifest), 0600))
+
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "operator.kyma-project.io/v1beta2",
+			"kind":       "ModuleTemplate",
+			"metadata": map[string]interface{}{
+				"name":      "cap-" + version,
+				"namespace": "kyma-system",
+			},
+			"spec": map[string]interface{}{
+				"moduleName": "cap",
+				"version":    version,
+				"data": map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Secret",
+					"metadata": map[string]interface{}{
+						"name": "cap-default",
+					},
+				},
+				"resources": []interface{}{
+					map[string]interface{}{
+						"name": "rawManifest",
+						"link": server.URL + "/manager.yaml",
+					},
+					map[string]interface{}{
+						"name": "crds",
+						"link": "file://" + crdPath,
+					},
+				},
+			},
+		},
+	}
+}
+
+func fixCommunityKubeClient(t *testing.T, objs ...runtime.Object) (dynamic.Interface, *kube_fake.FakeKubeClient) {
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypes(kyma.GVRModuleTemplate.GroupVersion())
+	dynamic := dynamic_fake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
+		kyma.GVRModuleTemplate:    "ModuleTemplateList",
+		kyma.GVRModuleReleaseMeta: "ModuleReleaseMetaList",
+		kyma.GVRKyma:              "KymaList",
+	}, objs...)
+
+	return dynamic, &kube_fake.FakeKubeClient{
+		TestKymaInterface: kyma.NewClient(dynamic),
+		TestRootlessDynamicInterface: rootlessdynamic.NewClientWithApplyFunc(dynamic, &discovery_fake.FakeDiscovery{
+			Fake: &clientgo_testing.Fake{
+				Resources: []*metav1.APIResourceList{
+					{
+						GroupVersion: "v1",
+						APIResources: []metav1.APIResource{
+							{Version: "v1", Kind: "Namespace", Name: "namespaces"},
+							{Version: "v1", Kind: "ConfigMap", Name: "configmaps", Namespaced: true},
+							{Version: "v1", Kind: "ServiceAccount", Name: "serviceaccounts", Namespaced: true},
+							{Version: "v1", Kind: "Secret", Name: "secrets", Namespaced: true},
+						},
+					},
+				},
+			},
+		}, fixCreateOrUpdateFunc),
+	}
+}
+
+// this func is a testing version of the Apply func that can't be used in tests because of dynamic.FakeDynamicClient limitations
+func fixCreateOrUpdateFunc(ctx context.Context, ri dynamic.ResourceInterface, u *unstructured.Unstructured) error {
+	_, err := ri.Create(ctx, u, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		_, err = ri.Update(ctx, u, metav1.UpdateOptions{})
+	}
+
+	return err
+}
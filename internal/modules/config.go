@@ -0,0 +1,211 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/kyma-project/cli.v3/internal/kube"
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// GetConfig returns the live module CR from the cluster
+// the CR is pointed by the manager of the installed module version or by its default CR if the manager is not set
+func GetConfig(ctx context.Context, client kube.Client, module string) (*unstructured.Unstructured, clierror.Error) {
+	moduleTemplate, clierr := findConfigModuleTemplate(ctx, client.Kyma(), module)
+	if clierr != nil {
+		return nil, clierr
+	}
+
+	target, clierr := getConfigTarget(moduleTemplate)
+	if clierr != nil {
+		return nil, clierr
+	}
+
+	moduleCR, err := client.RootlessDynamic().Get(ctx, target)
+	if apierrors.IsNotFound(err) {
+		return nil, clierror.Wrap(err, clierror.New(fmt.Sprintf("%s CR of the %s module does not exist in the cluster", target.GetKind(), module),
+			"Use the 'kyma alpha modules config apply --reset' command to create it with the default configuration"))
+	}
+	if err != nil {
+		return nil, clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to get the %s CR of the %s module", target.GetKind(), module)))
+	}
+
+	return moduleCR, nil
+}
+
+// ApplyConfig applies spec of the given module CR to the live module CR using server-side apply
+// only the spec is applied so fields of the metadata and status owned by the module operator are not taken over
+// the CR must be of the same kind as the live module CR, its apiVersion and kind are used if the CR has no kind
+func ApplyConfig(ctx context.Context, client kube.Client, module string, moduleCR *unstructured.Unstructured) clierror.Error {
+	moduleTemplate, clierr := findConfigModuleTemplate(ctx, client.Kyma(), module)
+	if clierr != nil {
+		return clierr
+	}
+
+	target, clierr := getConfigTarget(moduleTemplate)
+	if clierr != nil {
+		return clierr
+	}
+
+	if moduleCR.GetKind() != "" && (moduleCR.GetAPIVersion() != target.GetAPIVersion() || moduleCR.GetKind() != target.GetKind()) {
+		return clierror.New(fmt.Sprintf("unexpected %s %s resource for the %s module", moduleCR.GetAPIVersion(), moduleCR.GetKind(), module),
+			fmt.Sprintf("Provide the %s %s resource", target.GetAPIVersion(), target.GetKind()))
+	}
+
+	if moduleCR.GetName() != "" {
+		target.SetName(moduleCR.GetName())
+	}
+	if moduleCR.GetNamespace() != "" {
+		target.SetNamespace(moduleCR.GetNamespace())
+	}
+
+	err := client.RootlessDynamic().Apply(ctx, buildConfigSpecPatch(target, moduleCR))
+	if err != nil {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to apply the %s CR of the %s module", target.GetKind(), module)))
+	}
+
+	return nil
+}
+
+// ResetConfig applies spec of the default CR from the ModuleTemplate of the installed module version to the live module CR
+// fields previously applied by the CLI and missing in the default CR are removed from the module CR
+func ResetConfig(ctx context.Context, client kube.Client, module string) clierror.Error {
+	moduleTemplate, clierr := findConfigModuleTemplate(ctx, client.Kyma(), module)
+	if clierr != nil {
+		return clierr
+	}
+
+	defaultCR, clierr := getConfigDefaultCR(moduleTemplate)
+	if clierr != nil {
+		return clierr
+	}
+
+	target, clierr := getConfigTarget(moduleTemplate)
+	if clierr != nil {
+		return clierr
+	}
+
+	if defaultCR.GetAPIVersion() != target.GetAPIVersion() || defaultCR.GetKind() != target.GetKind() {
+		return clierror.New(fmt.Sprintf("default CR of the %s module is not the %s %s resource pointed by the module manager", module, target.GetAPIVersion(), target.GetKind()),
+			"The module can't be reset to the default configuration")
+	}
+
+	err := client.RootlessDynamic().Apply(ctx, buildConfigSpecPatch(target, defaultCR))
+	if err != nil {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to reset the %s CR of the %s module", target.GetKind(), module)))
+	}
+
+	return nil
+}
+
+// findConfigModuleTemplate returns ModuleTemplate of the installed module version
+// or ModuleTemplate of the latest available version if the module is not installed
+func findConfigModuleTemplate(ctx context.Context, client kyma.Interface, module string) (*kyma.ModuleTemplate, clierror.Error) {
+	moduleTemplates, err := client.ListModuleTemplate(ctx)
+	if err != nil {
+		return nil, clierror.Wrap(err, clierror.New("failed to list available modules from the cluster"))
+	}
+
+	defaultKyma, err := client.GetDefaultKyma(ctx)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, clierror.Wrap(err, clierror.New("failed to get the default Kyma CR from the cluster"))
+	}
+
+	if defaultKyma != nil {
+		installedVersion := getInstalledVersion(defaultKyma, module)
+		if moduleTemplate := findModuleTemplate(moduleTemplates, module, installedVersion); moduleTemplate != nil {
+			return moduleTemplate, nil
+		}
+	}
+
+	var latest *kyma.ModuleTemplate
+	for i := range moduleTemplates.Items {
+		moduleTemplate := &moduleTemplates.Items[i]
+		if moduleTemplate.Spec.ModuleName != module {
+			continue
+		}
+
+		if latest == nil || compareVersions(moduleTemplate.Spec.Version, latest.Spec.Version) > 0 {
+			latest = moduleTemplate
+		}
+	}
+
+	if latest == nil {
+		return nil, clierror.New(fmt.Sprintf("module %s is not available on the cluster", module),
+			"Use the 'kyma alpha modules list' command to see available modules")
+	}
+
+	return latest, nil
+}
+
+func getConfigDefaultCR(moduleTemplate *kyma.ModuleTemplate) (*unstructured.Unstructured, clierror.Error) {
+	if moduleTemplate.Spec.Data.GetKind() == "" {
+		return nil, clierror.New(fmt.Sprintf("module %s has no default CR defined in the ModuleTemplate", moduleTemplate.Spec.ModuleName),
+			"The module can't be configured with a CR")
+	}
+
+	return getDefaultCR(moduleTemplate), nil
+}
+
+// getConfigTarget returns reference to the live module CR
+// it's pointed by the ModuleTemplate manager, the default CR is used only if the manager is not set
+func getConfigTarget(moduleTemplate *kyma.ModuleTemplate) (*unstructured.Unstructured, clierror.Error) {
+	manager := moduleTemplate.Spec.Manager
+	if manager.Kind == "" {
+		defaultCR, clierr := getConfigDefaultCR(moduleTemplate)
+		if clierr != nil {
+			return nil, clierr
+		}
+
+		target := &unstructured.Unstructured{}
+		target.SetAPIVersion(defaultCR.GetAPIVersion())
+		target.SetKind(defaultCR.GetKind())
+		target.SetName(defaultCR.GetName())
+		target.SetNamespace(defaultCR.GetNamespace())
+		return target, nil
+	}
+
+	target := &unstructured.Unstructured{}
+	target.SetAPIVersion(metav1.GroupVersion{Group: manager.Group, Version: manager.Version}.String())
+	target.SetKind(manager.Kind)
+	target.SetName(manager.Name)
+	target.SetNamespace(manager.Namespace)
+	if target.GetNamespace() == "" {
+		target.SetNamespace(kyma.DefaultKymaNamespace)
+	}
+	return target, nil
+}
+
+// buildConfigSpecPatch returns the object with the target identity and only the spec of the source
+// an object without spec removes spec fields previously applied by the CLI
+func buildConfigSpecPatch(target, source *unstructured.Unstructured) *unstructured.Unstructured {
+	obj := target.DeepCopy()
+	if spec, found := source.Object["spec"]; found {
+		obj.Object["spec"] = runtime.DeepCopyJSONValue(spec)
+	}
+
+	return obj
+}
+
+// RenderConfig renders module CR to the writer in the given format
+// the yaml format is used if the format is empty
+func RenderConfig(writer io.Writer, moduleCR *unstructured.Unstructured, format types.Format) error {
+	obj := moduleCR.DeepCopy()
+	unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+
+	switch format {
+	case types.JSONFormat:
+		return types.RenderJSON(writer, obj.Object)
+	case types.DefaultFormat, types.YAMLFormat:
+		return types.RenderYAML(writer, obj.Object)
+	default:
+		return fmt.Errorf("unsupported format '%s'", format)
+	}
+}
@@ -0,0 +1,233 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGetConfig(t *testing.T) {
+	t.Run("get module CR", func(t *testing.T) {
+		_, client := fixCommunityKubeClient(t, fixCommunityModuleTemplate(t, "0.0.1"), fixTestSecret("cap-default"))
+
+		moduleCR, clierr := GetConfig(context.Background(), client, "cap")
+		require.Nil(t, clierr)
+		require.Equal(t, "cap-default", moduleCR.GetName())
+		require.Equal(t, "kyma-system", moduleCR.GetNamespace())
+	})
+
+	t.Run("get module CR pointed by the manager", func(t *testing.T) {
+		_, client := fixCommunityKubeClient(t, fixConfigModuleTemplateWithManager(t, "cap-live"), fixTestSecret("cap-default"), fixTestSecret("cap-live"))
+
+		moduleCR, clierr := GetConfig(context.Background(), client, "cap")
+		require.Nil(t, clierr)
+		require.Equal(t, "cap-live", moduleCR.GetName())
+		require.Equal(t, "kyma-system", moduleCR.GetNamespace())
+	})
+
+	t.Run("module CR does not exist", func(t *testing.T) {
+		_, client := fixCommunityKubeClient(t, fixCommunityModuleTemplate(t, "0.0.1"))
+
+		moduleCR, clierr := GetConfig(context.Background(), client, "cap")
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "Secret CR of the cap module does not exist in the cluster")
+		require.Nil(t, moduleCR)
+	})
+
+	t.Run("module not available", func(t *testing.T) {
+		_, client := fixCommunityKubeClient(t, fixCommunityModuleTemplate(t, "0.0.1"))
+
+		moduleCR, clierr := GetConfig(context.Background(), client, "unknown")
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "module unknown is not available on the cluster")
+		require.Nil(t, moduleCR)
+	})
+}
+
+func TestApplyConfig(t *testing.T) {
+	t.Run("apply module CR", func(t *testing.T) {
+		_, client := fixCommunityKubeClient(t, fixCommunityModuleTemplate(t, "0.0.1"))
+
+		moduleCR := fixTestSecret("")
+		moduleCR.SetNamespace("")
+		moduleCR.SetResourceVersion("123")
+		moduleCR.Object["spec"] = map[string]interface{}{"key": "value"}
+
+		clierr := ApplyConfig(context.Background(), client, "cap", moduleCR)
+		require.Nil(t, clierr)
+
+		applied, clierr := GetConfig(context.Background(), client, "cap")
+		require.Nil(t, clierr)
+		require.Equal(t, "cap-default", applied.GetName())
+		require.Equal(t, "kyma-system", applied.GetNamespace())
+		require.Equal(t, map[string]interface{}{"key": "value"}, applied.Object["spec"])
+	})
+
+	t.Run("apply only spec of the module CR pointed by the manager", func(t *testing.T) {
+		_, client := fixCommunityKubeClient(t, fixConfigModuleTemplateWithManager(t, "cap-live"))
+
+		moduleCR := fixTestSecret("")
+		moduleCR.SetLabels(map[string]string{"owner": "operator"})
+		moduleCR.SetFinalizers([]string{"operator.kyma-project.io/finalizer"})
+		moduleCR.Object["spec"] = map[string]interface{}{"key": "value"}
+		moduleCR.Object["status"] = map[string]interface{}{"state": "Ready"}
+
+		clierr := ApplyConfig(context.Background(), client, "cap", moduleCR)
+		require.Nil(t, clierr)
+
+		applied, clierr := GetConfig(context.Background(), client, "cap")
+		require.Nil(t, clierr)
+		require.Equal(t, map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata": map[string]interface{}{
+				"name":      "cap-live",
+				"namespace": "kyma-system",
+			},
+			"spec": map[string]interface{}{"key": "value"},
+		}, applied.Object)
+	})
+
+	t.Run("unexpected resource kind", func(t *testing.T) {
+		_, client := fixCommunityKubeClient(t, fixCommunityModuleTemplate(t, "0.0.1"))
+
+		moduleCR := fixTestSecret("cap-default")
+		moduleCR.SetKind("ConfigMap")
+
+		clierr := ApplyConfig(context.Background(), client, "cap", moduleCR)
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "unexpected v1 ConfigMap resource for the cap module")
+		require.Contains(t, clierr.String(), "Provide the v1 Secret resource")
+	})
+}
+
+func TestResetConfig(t *testing.T) {
+	t.Run("reset module CR to default", func(t *testing.T) {
+		_, client := fixCommunityKubeClient(t, fixCommunityModuleTemplate(t, "0.0.1"))
+
+		clierr := ResetConfig(context.Background(), client, "cap")
+		require.Nil(t, clierr)
+
+		moduleCR, clierr := GetConfig(context.Background(), client, "cap")
+		require.Nil(t, clierr)
+		require.Equal(t, "cap-default", moduleCR.GetName())
+		require.Equal(t, "kyma-system", moduleCR.GetNamespace())
+	})
+
+	t.Run("module without default CR", func(t *testing.T) {
+		moduleTemplate := fixCommunityModuleTemplate(t, "0.0.1")
+		unstructured.RemoveNestedField(moduleTemplate.Object, "spec", "data")
+		_, client := fixCommunityKubeClient(t, moduleTemplate)
+
+		clierr := ResetConfig(context.Background(), client, "cap")
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "module cap has no default CR defined in the ModuleTemplate")
+	})
+}
+
+func Test_findConfigModuleTemplate(t *testing.T) {
+	t.Run("use latest version if module is not installed", func(t *testing.T) {
+		_, client := fixCommunityKubeClient(t,
+			fixCommunityModuleTemplate(t, "0.10.0"),
+			fixCommunityModuleTemplate(t, "0.9.0"),
+		)
+
+		moduleTemplate, clierr := findConfigModuleTemplate(context.Background(), client.Kyma(), "cap")
+		require.Nil(t, clierr)
+		require.Equal(t, "0.10.0", moduleTemplate.Spec.Version)
+	})
+
+	t.Run("use installed version", func(t *testing.T) {
+		_, client := fixCommunityKubeClient(t,
+			fixCommunityModuleTemplate(t, "0.10.0"),
+			fixCommunityModuleTemplate(t, "0.9.0"),
+			&unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "operator.kyma-project.io/v1beta2",
+					"kind":       "Kyma",
+					"metadata": map[string]interface{}{
+						"name":      "default",
+						"namespace": "kyma-system",
+					},
+					"status": map[string]interface{}{
+						"modules": []interface{}{
+							map[string]interface{}{
+								"name":    "cap",
+								"version": "0.9.0",
+							},
+						},
+					},
+				},
+			},
+		)
+
+		moduleTemplate, clierr := findConfigModuleTemplate(context.Background(), client.Kyma(), "cap")
+		require.Nil(t, clierr)
+		require.Equal(t, "0.9.0", moduleTemplate.Spec.Version)
+	})
+}
+
+func TestRenderConfig(t *testing.T) {
+	moduleCR := fixTestSecret("cap-default")
+	moduleCR.Object["metadata"].(map[string]interface{})["managedFields"] = []interface{}{
+		map[string]interface{}{"manager": "cli"},
+	}
+
+	t.Run("yaml", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+
+		err := RenderConfig(buffer, moduleCR, types.DefaultFormat)
+		require.NoError(t, err)
+		require.Equal(t, `apiVersion: v1
+kind: Secret
+metadata:
+  name: cap-default
+  namespace: kyma-system
+`, buffer.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+
+		err := RenderConfig(buffer, moduleCR, types.JSONFormat)
+		require.NoError(t, err)
+		require.Equal(t, `{
+  "apiVersion": "v1",
+  "kind": "Secret",
+  "metadata": {
+    "name": "cap-default",
+    "namespace": "kyma-system"
+  }
+}
+`, buffer.String())
+	})
+}
+
+func fixConfigModuleTemplateWithManager(t *testing.T, name string) *unstructured.Unstructured {
+	moduleTemplate := fixCommunityModuleTemplate(t, "0.0.1")
+	moduleTemplate.Object["spec"].(map[string]interface{})["manager"] = map[string]interface{}{
+		"group":     "",
+		"version":   "v1",
+		"kind":      "Secret",
+		"name":      name,
+		"namespace": "kyma-system",
+	}
+	return moduleTemplate
+}
+
+func fixTestSecret(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "kyma-system",
+			},
+		},
+	}
+}
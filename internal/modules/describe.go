@@ -0,0 +1,203 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/kyma-project/cli.v3/internal/kube"
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ModuleDescription struct {
+	Name           string
+	InstallDetails ModuleInstallDetails
+	Versions       []ModuleVersionDescription
+}
+
+type ModuleVersionDescription struct {
+	Version             string                    `json:"version" yaml:"version"`
+	Channels            []string                  `json:"channels,omitempty" yaml:"channels,omitempty"`
+	Repository          string                    `json:"repository,omitempty" yaml:"repository,omitempty"`
+	Documentation       string                    `json:"documentation,omitempty" yaml:"documentation,omitempty"`
+	Mandatory           bool                      `json:"mandatory" yaml:"mandatory"`
+	Icons               []kyma.ModuleIcon         `json:"icons,omitempty" yaml:"icons,omitempty"`
+	Manager             *ModuleManager            `json:"manager,omitempty" yaml:"manager,omitempty"`
+	AssociatedResources []metav1.GroupVersionKind `json:"associatedResources,omitempty" yaml:"associatedResources,omitempty"`
+}
+
+type ModuleManager struct {
+	Group     string `json:"group" yaml:"group"`
+	Version   string `json:"version" yaml:"version"`
+	Kind      string `json:"kind" yaml:"kind"`
+	Name      string `json:"name" yaml:"name"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+}
+
+// structured representation of the module description used to render json and yaml outputs
+type moduleDescriptionOutput struct {
+	Name           string                     `json:"name" yaml:"name"`
+	InstallDetails *installDetailsOutput      `json:"installDetails,omitempty" yaml:"installDetails,omitempty"`
+	Versions       []ModuleVersionDescription `json:"versions" yaml:"versions"`
+}
+
+// Describe collects all information about the module from its ModuleTemplates, ModuleReleaseMeta and the default Kyma CR
+func Describe(ctx context.Context, client kube.Client, module string) (*ModuleDescription, error) {
+	// the same resources are used to build the modules list and to describe versions
+	data, err := loadModulesData(ctx, client, ListOptions{ModuleNames: []string{module}})
+	if err != nil {
+		return nil, err
+	}
+
+	modulesList := buildModulesList(ctx, client, data, ListOptions{ModuleNames: []string{module}})
+	i := getModuleIndex(modulesList, module)
+	if i == -1 {
+		return nil, fmt.Errorf("module %s not found", module)
+	}
+
+	description := &ModuleDescription{
+		Name:           module,
+		InstallDetails: modulesList[i].InstallDetails,
+	}
+	for _, moduleTemplate := range data.moduleTemplates.Items {
+		if moduleTemplate.Spec.ModuleName != module {
+			continue
+		}
+
+		description.Versions = append(description.Versions, describeVersion(moduleTemplate, data.releaseMetas))
+	}
+
+	slices.SortFunc(description.Versions, func(a, b ModuleVersionDescription) int {
+		return compareVersions(a.Version, b.Version)
+	})
+
+	return description, nil
+}
+
+func describeVersion(moduleTemplate kyma.ModuleTemplate, releaseMetas *kyma.ModuleReleaseMetaList) ModuleVersionDescription {
+	spec := moduleTemplate.Spec
+	versionDescription := ModuleVersionDescription{
+		Version:             spec.Version,
+		Channels:            getAssignedChannels(releaseMetas, spec.ModuleName, spec.Version),
+		Repository:          spec.Info.Repository,
+		Documentation:       spec.Info.Documentation,
+		Mandatory:           spec.Mandatory,
+		Icons:               spec.Info.Icons,
+		AssociatedResources: spec.AssociatedResources,
+	}
+
+	if spec.Manager.Kind != "" {
+		versionDescription.Manager = &ModuleManager{
+			Group:     spec.Manager.Group,
+			Version:   spec.Manager.Version,
+			Kind:      spec.Manager.Kind,
+			Name:      spec.Manager.Name,
+			Namespace: spec.Manager.Namespace,
+		}
+	}
+
+	return versionDescription
+}
+
+// look for all channels assigned to the version of the module
+func getAssignedChannels(releaseMetas *kyma.ModuleReleaseMetaList, moduleName, version string) []string {
+	channels := []string{}
+	for _, releaseMeta := range releaseMetas.Items {
+		if releaseMeta.Spec.ModuleName != moduleName {
+			continue
+		}
+
+		for _, assignment := range releaseMeta.Spec.Channels {
+			if assignment.Version == version {
+				channels = append(channels, assignment.Channel)
+			}
+		}
+	}
+
+	slices.Sort(channels)
+	return channels
+}
+
+// RenderDescription renders module description to the stdout in the given format
+func RenderDescription(description *ModuleDescription, format types.Format) error {
+	return renderDescription(os.Stdout, description, format)
+}
+
+func renderDescription(writer io.Writer, description *ModuleDescription, format types.Format) error {
+	output := moduleDescriptionOutput{
+		Name:           description.Name,
+		InstallDetails: convertInstallDetailsToOutput(description.InstallDetails),
+		Versions:       description.Versions,
+	}
+
+	return types.RenderFormat(writer, output, format, func(writer io.Writer) {
+		renderDescriptionText(writer, description)
+	})
+}
+
+func renderDescriptionText(writer io.Writer, description *ModuleDescription) {
+	tw := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	defer tw.Flush()
+
+	fmt.Fprintf(tw, "Name:\t%s\n", description.Name)
+	if description.InstallDetails != (ModuleInstallDetails{}) {
+		fmt.Fprintf(tw, "Installed:\t%s\n", convertInstall(description.InstallDetails))
+		fmt.Fprintf(tw, "Managed:\t%s\n", description.InstallDetails.Managed)
+		fmt.Fprintf(tw, "State:\t%s\n", types.ValueOrDash(description.InstallDetails.State))
+	} else {
+		fmt.Fprintf(tw, "Installed:\t-\n")
+	}
+
+	fmt.Fprintf(tw, "Versions:\n")
+	for _, version := range description.Versions {
+		fmt.Fprintf(tw, "  %s\n", version.Version)
+		fmt.Fprintf(tw, "    Channels:\t%s\n", types.ValueOrDash(strings.Join(version.Channels, ", ")))
+		fmt.Fprintf(tw, "    Repository:\t%s\n", types.ValueOrDash(version.Repository))
+		fmt.Fprintf(tw, "    Documentation:\t%s\n", types.ValueOrDash(version.Documentation))
+		fmt.Fprintf(tw, "    Mandatory:\t%t\n", version.Mandatory)
+		fmt.Fprintf(tw, "    Manager:\t%s\n", describeManager(version.Manager))
+		fmt.Fprintf(tw, "    Icons:\t%s\n", types.ValueOrDash(describeIcons(version.Icons)))
+		fmt.Fprintf(tw, "    Associated resources:\t%s\n", types.ValueOrDash(describeGVKs(version.AssociatedResources)))
+	}
+}
+
+// convert icons to the format 'name (link), name (link)'
+func describeIcons(icons []kyma.ModuleIcon) string {
+	values := make([]string, len(icons))
+	for i, icon := range icons {
+		values[i] = fmt.Sprintf("%s (%s)", icon.Name, icon.Link)
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// convert gvks to the format 'Kind group/version, Kind group/version'
+func describeGVKs(gvks []metav1.GroupVersionKind) string {
+	values := make([]string, len(gvks))
+	for i, gvk := range gvks {
+		values[i] = fmt.Sprintf("%s %s", gvk.Kind, metav1.GroupVersion{Group: gvk.Group, Version: gvk.Version}.String())
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// convert manager to the format 'Kind group/version namespace/name'
+func describeManager(manager *ModuleManager) string {
+	if manager == nil {
+		return "-"
+	}
+
+	apiVersion := metav1.GroupVersion{Group: manager.Group, Version: manager.Version}.String()
+	name := manager.Name
+	if manager.Namespace != "" {
+		name = fmt.Sprintf("%s/%s", manager.Namespace, manager.Name)
+	}
+
+	return fmt.Sprintf("%s %s %s", manager.Kind, apiVersion, name)
+}
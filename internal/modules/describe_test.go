@@ -0,0 +1,250 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	kube_fake "github.com/kyma-project/cli.v3/internal/kube/fake"
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamic_fake "k8s.io/client-go/dynamic/fake"
+)
+
+const (
+	testDescriptionText = `Name:       keda
+Installed:  0.2(fast)
+Managed:    true
+State:      Ready
+Versions:
+  0.1
+    Channels:              regular
+    Repository:            url-3
+    Documentation:         -
+    Mandatory:             false
+    Manager:               -
+    Icons:                 -
+    Associated resources:  -
+  0.2
+    Channels:              fast
+    Repository:            -
+    Documentation:         -
+    Mandatory:             false
+    Manager:               Deployment apps/v1 kyma-system/keda-manager
+    Icons:                 -
+    Associated resources:  -
+`
+
+	testDescriptionJSON = `{
+  "name": "keda",
+  "installDetails": {
+    "version": "0.2",
+    "channel": "fast",
+    "managed": true,
+    "state": "Ready"
+  },
+  "versions": [
+    {
+      "version": "0.1",
+      "channels": [
+        "regular"
+      ],
+      "repository": "url-3",
+      "mandatory": false
+    },
+    {
+      "version": "0.2",
+      "channels": [
+        "fast"
+      ],
+      "mandatory": false,
+      "manager": {
+        "group": "apps",
+        "version": "v1",
+        "kind": "Deployment",
+        "name": "keda-manager",
+        "namespace": "kyma-system"
+      }
+    }
+  ]
+}
+`
+
+	testDescriptionYAML = `name: keda
+installDetails:
+  version: "0.2"
+  channel: fast
+  managed: true
+  state: Ready
+versions:
+  - version: "0.1"
+    channels:
+      - regular
+    repository: url-3
+    mandatory: false
+  - version: "0.2"
+    channels:
+      - fast
+    mandatory: false
+    manager:
+      group: apps
+      version: v1
+      kind: Deployment
+      name: keda-manager
+      namespace: kyma-system
+`
+)
+
+var testKedaDescription = ModuleDescription{
+	Name: "keda",
+	InstallDetails: ModuleInstallDetails{
+		Managed: ManagedTrue,
+		Channel: "fast",
+		Version: "0.2",
+		State:   "Ready",
+	},
+	Versions: []ModuleVersionDescription{
+		{
+			Version:    "0.1",
+			Channels:   []string{"regular"},
+			Repository: "url-3",
+		},
+		{
+			Version:  "0.2",
+			Channels: []string{"fast"},
+			Manager: &ModuleManager{
+				Group:     "apps",
+				Version:   "v1",
+				Kind:      "Deployment",
+				Name:      "keda-manager",
+				Namespace: "kyma-system",
+			},
+		},
+	},
+}
+
+func TestDescribe(t *testing.T) {
+	t.Run("describe installed module", func(t *testing.T) {
+		client := fixDescribeKubeClient()
+
+		description, err := Describe(context.Background(), client, "keda")
+		require.NoError(t, err)
+		require.Equal(t, &testKedaDescription, description)
+	})
+
+	t.Run("describe not installed module", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		scheme.AddKnownTypes(kyma.GVRModuleTemplate.GroupVersion())
+		dynamicClient := dynamic_fake.NewSimpleDynamicClient(scheme,
+			&testModuleTemplate2,
+			&testModuleTemplate1,
+			&testReleaseMeta1,
+		)
+
+		description, err := Describe(context.Background(), &kube_fake.FakeKubeClient{
+			TestKymaInterface: kyma.NewClient(dynamicClient),
+		}, "serverless")
+		require.NoError(t, err)
+		require.Equal(t, &ModuleDescription{
+			Name: "serverless",
+			Versions: []ModuleVersionDescription{
+				{
+					Version:    "0.0.1",
+					Channels:   []string{"fast"},
+					Repository: "url-1",
+				},
+				{
+					Version:    "0.0.2",
+					Channels:   []string{},
+					Repository: "url-2",
+				},
+			},
+		}, description)
+	})
+
+	t.Run("list resources once", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		scheme.AddKnownTypes(kyma.GVRModuleTemplate.GroupVersion())
+		dynamicClient := dynamic_fake.NewSimpleDynamicClient(scheme, &testModuleTemplate1, &testReleaseMeta1)
+
+		_, err := Describe(context.Background(), &kube_fake.FakeKubeClient{
+			TestKymaInterface: kyma.NewClient(dynamicClient),
+		}, "serverless")
+		require.NoError(t, err)
+
+		listedResources := []string{}
+		for _, action := range dynamicClient.Actions() {
+			if action.GetVerb() == "list" {
+				listedResources = append(listedResources, action.GetResource().Resource)
+			}
+		}
+		require.ElementsMatch(t, []string{"moduletemplates", "modulereleasemetas"}, listedResources)
+	})
+
+	t.Run("module not found", func(t *testing.T) {
+		client := fixDescribeKubeClient()
+
+		description, err := Describe(context.Background(), client, "unknown")
+		require.ErrorContains(t, err, "module unknown not found")
+		require.Nil(t, description)
+	})
+}
+
+func Test_renderDescription(t *testing.T) {
+	tests := []struct {
+		name   string
+		format types.Format
+		want   string
+	}{
+		{
+			name:   "text",
+			format: types.DefaultFormat,
+			want:   testDescriptionText,
+		},
+		{
+			name:   "json",
+			format: types.JSONFormat,
+			want:   testDescriptionJSON,
+		},
+		{
+			name:   "yaml",
+			format: types.YAMLFormat,
+			want:   testDescriptionYAML,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := bytes.NewBuffer([]byte{})
+
+			err := renderDescription(buffer, &testKedaDescription, tt.format)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, buffer.String())
+		})
+	}
+}
+
+func fixDescribeKubeClient() *kube_fake.FakeKubeClient {
+	kedaTemplate := testModuleTemplate4.DeepCopy()
+	kedaTemplate.Object["spec"].(map[string]interface{})["manager"] = map[string]interface{}{
+		"group":     "apps",
+		"version":   "v1",
+		"kind":      "Deployment",
+		"name":      "keda-manager",
+		"namespace": "kyma-system",
+	}
+
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypes(kyma.GVRModuleTemplate.GroupVersion())
+	dynamicClient := dynamic_fake.NewSimpleDynamicClient(scheme,
+		&testModuleTemplate3,
+		kedaTemplate,
+		&testReleaseMeta2,
+		&testKymaCR,
+	)
+
+	return &kube_fake.FakeKubeClient{
+		TestKymaInterface: kyma.NewClient(dynamicClient),
+	}
+}
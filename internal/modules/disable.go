@@ -0,0 +1,154 @@
+package modules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/kube"
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"github.com/kyma-project/cli.v3/internal/kube/rootlessdynamic"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Disable removes module from the default Kyma CR
+// if force is false it fails when there are still resources in the cluster that block the module deletion
+func Disable(ctx context.Context, client kube.Client, module string, force bool) clierror.Error {
+	if !force {
+		clierr := checkBlockingResources(ctx, client, module)
+		if clierr != nil {
+			return clierr
+		}
+	}
+
+	err := client.Kyma().DisableModule(ctx, module)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to remove module from the default Kyma CR",
+			"Make sure the Kyma CR exists in the kyma-system namespace"))
+	}
+
+	return nil
+}
+
+// checkBlockingResources returns error with list of resources blocking the module deletion if there are any
+func checkBlockingResources(ctx context.Context, client kube.Client, module string) clierror.Error {
+	blockingResources, clierr := FindBlockingResources(ctx, client, module)
+	if clierr != nil {
+		return clierr
+	}
+
+	if len(blockingResources) > 0 {
+		return clierror.Wrap(
+			errors.New(strings.Join(blockingResources, ", ")),
+			clierror.New(fmt.Sprintf("found resources blocking deletion of the %s module", module),
+				"Remove listed resources before deleting the module",
+				"Use the --force flag to delete the module anyway"),
+		)
+	}
+
+	return nil
+}
+
+// FindBlockingResources returns resources of kinds associated with the module that still exist in the cluster
+// every resource is described in the format 'Kind namespace/name' or 'Kind name' for cluster-scoped ones
+func FindBlockingResources(ctx context.Context, client kube.Client, module string) ([]string, clierror.Error) {
+	associatedResources, clierr := getAssociatedResources(ctx, client.Kyma(), module)
+	if clierr != nil {
+		return nil, clierr
+	}
+
+	blockingResources := []string{}
+	for _, gvk := range associatedResources {
+		list, err := client.RootlessDynamic().List(ctx, &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": metav1.GroupVersion{Group: gvk.Group, Version: gvk.Version}.String(),
+				"kind":       gvk.Kind,
+			},
+		})
+		if apierrors.IsNotFound(err) || errors.Is(err, rootlessdynamic.ErrKindNotRegistered) {
+			// resource is not registered in the cluster so there is nothing to block deletion
+			continue
+		}
+		if err != nil {
+			return nil, clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to list %s resources", gvk.Kind)))
+		}
+
+		for _, item := range list.Items {
+			blockingResources = append(blockingResources, describeResource(gvk.Kind, item))
+		}
+	}
+
+	return blockingResources, nil
+}
+
+// getAssociatedResources returns associated resources of the installed module version
+// or associated resources of all module versions if the module is not installed
+func getAssociatedResources(ctx context.Context, client kyma.Interface, module string) ([]metav1.GroupVersionKind, clierror.Error) {
+	moduleTemplates, err := client.ListModuleTemplate(ctx)
+	if err != nil {
+		return nil, clierror.Wrap(err, clierror.New("failed to list available modules from the cluster"))
+	}
+
+	defaultKyma, err := client.GetDefaultKyma(ctx)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, clierror.Wrap(err, clierror.New("failed to get the default Kyma CR from the cluster"))
+	}
+
+	installedVersion := ""
+	if defaultKyma != nil {
+		installedVersion = getInstalledVersion(defaultKyma, module)
+	}
+
+	installed := []metav1.GroupVersionKind{}
+	all := []metav1.GroupVersionKind{}
+	for _, moduleTemplate := range moduleTemplates.Items {
+		if moduleTemplate.Spec.ModuleName != module {
+			continue
+		}
+
+		all = appendUniqueGVKs(all, moduleTemplate.Spec.AssociatedResources...)
+		if moduleTemplate.Spec.Version == installedVersion {
+			installed = appendUniqueGVKs(installed, moduleTemplate.Spec.AssociatedResources...)
+		}
+	}
+
+	if installedVersion != "" && len(installed) > 0 {
+		return installed, nil
+	}
+
+	return all, nil
+}
+
+// look for installed version of the module with specified name in the Kyma CR status
+func getInstalledVersion(kymaCR *kyma.Kyma, moduleName string) string {
+	for _, module := range kymaCR.Status.Modules {
+		if module.Name == moduleName {
+			return module.Version
+		}
+	}
+
+	return ""
+}
+
+func appendUniqueGVKs(list []metav1.GroupVersionKind, gvks ...metav1.GroupVersionKind) []metav1.GroupVersionKind {
+	for _, gvk := range gvks {
+		if !slices.Contains(list, gvk) {
+			list = append(list, gvk)
+		}
+	}
+
+	return list
+}
+
+func describeResource(kind string, obj unstructured.Unstructured) string {
+	if obj.GetNamespace() != "" {
+		return fmt.Sprintf("%s %s/%s", kind, obj.GetNamespace(), obj.GetName())
+	}
+
+	return fmt.Sprintf("%s %s", kind, obj.GetName())
+}
@@ -0,0 +1,182 @@
+package modules
+
+import (
+	"context"
+	"testing"
+
+	kube_fake "github.com/kyma-project/cli.v3/internal/kube/fake"
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"github.com/kyma-project/cli.v3/internal/kube/rootlessdynamic"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	discovery_fake "k8s.io/client-go/discovery/fake"
+	dynamic_fake "k8s.io/client-go/dynamic/fake"
+	clientgo_testing "k8s.io/client-go/testing"
+)
+
+var (
+	testServerlessModuleTemplate = unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "operator.kyma-project.io/v1beta2",
+			"kind":       "ModuleTemplate",
+			"metadata": map[string]interface{}{
+				"name":      "serverless-0.0.1",
+				"namespace": "kyma-system",
+			},
+			"spec": map[string]interface{}{
+				"moduleName": "serverless",
+				"version":    "0.0.1",
+				"associatedResources": []interface{}{
+					map[string]interface{}{
+						"group":   "serverless.kyma-project.io",
+						"version": "v1alpha2",
+						"kind":    "Function",
+					},
+					map[string]interface{}{
+						// group version is registered but the kind isn't
+						"group":   "serverless.kyma-project.io",
+						"version": "v1alpha2",
+						"kind":    "NotRegistered",
+					},
+					map[string]interface{}{
+						"group":   "notregistered.kyma-project.io",
+						"version": "v1",
+						"kind":    "NotRegistered",
+					},
+				},
+			},
+		},
+	}
+
+	testServerlessKymaCR = unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "operator.kyma-project.io/v1beta2",
+			"kind":       "Kyma",
+			"metadata": map[string]interface{}{
+				"name":      kyma.DefaultKymaName,
+				"namespace": kyma.DefaultKymaNamespace,
+			},
+			"spec": map[string]interface{}{
+				"channel": "fast",
+				"modules": []interface{}{
+					map[string]interface{}{
+						"name": "serverless",
+					},
+				},
+			},
+			"status": map[string]interface{}{
+				"modules": []interface{}{
+					map[string]interface{}{
+						"name":    "serverless",
+						"version": "0.0.1",
+					},
+				},
+			},
+		},
+	}
+
+	testFunction = unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "serverless.kyma-project.io/v1alpha2",
+			"kind":       "Function",
+			"metadata": map[string]interface{}{
+				"name":      "test-function",
+				"namespace": "default",
+			},
+		},
+	}
+)
+
+func TestDisable(t *testing.T) {
+	t.Run("remove module from the Kyma CR", func(t *testing.T) {
+		client := fixDisableKubeClient()
+
+		clierr := Disable(context.Background(), client, "serverless", false)
+		require.Nil(t, clierr)
+
+		kymaCR, err := client.Kyma().GetDefaultKyma(context.Background())
+		require.NoError(t, err)
+		require.Empty(t, kymaCR.Spec.Modules)
+	})
+
+	t.Run("module deletion blocked by resources", func(t *testing.T) {
+		client := fixDisableKubeClient(&testFunction)
+
+		clierr := Disable(context.Background(), client, "serverless", false)
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "found resources blocking deletion of the serverless module")
+		require.Contains(t, clierr.String(), "Function default/test-function")
+
+		kymaCR, err := client.Kyma().GetDefaultKyma(context.Background())
+		require.NoError(t, err)
+		require.Len(t, kymaCR.Spec.Modules, 1)
+	})
+
+	t.Run("force module deletion", func(t *testing.T) {
+		client := fixDisableKubeClient(&testFunction)
+
+		clierr := Disable(context.Background(), client, "serverless", true)
+		require.Nil(t, clierr)
+
+		kymaCR, err := client.Kyma().GetDefaultKyma(context.Background())
+		require.NoError(t, err)
+		require.Empty(t, kymaCR.Spec.Modules)
+	})
+}
+
+func TestFindBlockingResources(t *testing.T) {
+	t.Run("find blocking resources", func(t *testing.T) {
+		client := fixDisableKubeClient(&testFunction)
+
+		resources, clierr := FindBlockingResources(context.Background(), client, "serverless")
+		require.Nil(t, clierr)
+		require.Equal(t, []string{"Function default/test-function"}, resources)
+	})
+
+	t.Run("no blocking resources for unknown module", func(t *testing.T) {
+		client := fixDisableKubeClient(&testFunction)
+
+		resources, clierr := FindBlockingResources(context.Background(), client, "keda")
+		require.Nil(t, clierr)
+		require.Empty(t, resources)
+	})
+}
+
+func fixDisableKubeClient(objs ...runtime.Object) *kube_fake.FakeKubeClient {
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypes(kyma.GVRModuleTemplate.GroupVersion())
+	scheme.AddKnownTypes(kyma.GVRModuleReleaseMeta.GroupVersion())
+	scheme.AddKnownTypes(kyma.GVRKyma.GroupVersion())
+	objs = append(objs, &testServerlessModuleTemplate, &testServerlessKymaCR)
+	dynamic := dynamic_fake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
+		{Group: "serverless.kyma-project.io", Version: "v1alpha2", Resource: "functions"}: "FunctionList",
+		kyma.GVRModuleTemplate:    "ModuleTemplateList",
+		kyma.GVRModuleReleaseMeta: "ModuleReleaseMetaList",
+		kyma.GVRKyma:              "KymaList",
+	}, objs...)
+
+	return &kube_fake.FakeKubeClient{
+		TestKymaInterface: kyma.NewClient(dynamic),
+		TestRootlessDynamicInterface: rootlessdynamic.NewClient(dynamic, &discovery_fake.FakeDiscovery{
+			Fake: &clientgo_testing.Fake{
+				Resources: []*metav1.APIResourceList{
+					{
+						GroupVersion: "serverless.kyma-project.io/v1alpha2",
+						APIResources: []metav1.APIResource{
+							{
+								Group:      "serverless.kyma-project.io",
+								Version:    "v1alpha2",
+								Kind:       "Function",
+								Name:       "functions",
+								Namespaced: true,
+							},
+						},
+					},
+				},
+			},
+		}),
+	}
+}
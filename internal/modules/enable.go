@@ -0,0 +1,137 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Enable adds module to the default Kyma CR after checking if module and channel are available on the cluster
+// if defaultCR is true the default module CR from the ModuleTemplate will be created by the lifecycle-manager
+func Enable(ctx context.Context, client kyma.Interface, module, channel string, defaultCR bool) clierror.Error {
+	clierr := validateModuleAndChannel(ctx, client, module, channel)
+	if clierr != nil {
+		return clierr
+	}
+
+	crPolicy := kyma.CustomResourcePolicyIgnore
+	if defaultCR {
+		crPolicy = kyma.CustomResourcePolicyCreateAndDelete
+	}
+
+	err := client.EnableModule(ctx, module, channel, crPolicy)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to add module to the default Kyma CR",
+			"Make sure the Kyma CR exists in the kyma-system namespace"))
+	}
+
+	return nil
+}
+
+// WaitForModuleState polls the default Kyma CR until the module is in the Ready state or fails
+// every observed state change is written to the writer
+func WaitForModuleState(ctx context.Context, client kyma.Interface, writer io.Writer, module string, interval time.Duration) clierror.Error {
+	lastState := ""
+	err := wait.PollUntilContextCancel(ctx, interval, true, func(ctx context.Context) (bool, error) {
+		kymaCR, err := client.GetDefaultKyma(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		state := getModuleState(kymaCR, module)
+		if state != "" && state != lastState {
+			fmt.Fprintf(writer, "module %s is in the %s state\n", module, state)
+			lastState = state
+		}
+
+		switch state {
+		case kyma.ModuleStateReady, kyma.ModuleStateWarning:
+			return true, nil
+		case kyma.ModuleStateError:
+			return false, fmt.Errorf("module %s is in the %s state", module, state)
+		default:
+			return false, nil
+		}
+	})
+	if err != nil {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to wait for the %s module to be ready", module),
+			"Check the module state in the Kyma CR status",
+			"Check the module CR and the module manager logs"))
+	}
+
+	return nil
+}
+
+func validateModuleAndChannel(ctx context.Context, client kyma.Interface, module, channel string) clierror.Error {
+	moduleTemplates, err := client.ListModuleTemplate(ctx)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to list available modules from the cluster"))
+	}
+
+	if channel == "" {
+		// default channel from the Kyma CR will be used
+		return checkModuleAndChannel(moduleTemplates, nil, module, channel)
+	}
+
+	releaseMetas, err := client.ListModuleReleaseMeta(ctx)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to list module release metas from the cluster"))
+	}
+
+	return checkModuleAndChannel(moduleTemplates, releaseMetas, module, channel)
+}
+
+// checkModuleAndChannel returns error if the module or its channel is not available in the given resources
+// release metas are not used if the channel is empty
+func checkModuleAndChannel(moduleTemplates *kyma.ModuleTemplateList, releaseMetas *kyma.ModuleReleaseMetaList, module, channel string) clierror.Error {
+	if !slices.ContainsFunc(moduleTemplates.Items, func(mt kyma.ModuleTemplate) bool {
+		return mt.Spec.ModuleName == module
+	}) {
+		return clierror.New(fmt.Sprintf("module %s is not available on the cluster", module),
+			"Use the 'kyma alpha modules list' command to see available modules")
+	}
+
+	if channel == "" {
+		return nil
+	}
+
+	channels := getAvailableChannels(*releaseMetas, module)
+	if !slices.Contains(channels, channel) {
+		return clierror.New(fmt.Sprintf("channel %s is not available for the %s module", channel, module),
+			fmt.Sprintf("Use one of the available channels: %s", strings.Join(channels, ", ")))
+	}
+
+	return nil
+}
+
+// look for all channels assigned to versions of the module with specified name
+func getAvailableChannels(releaseMetas kyma.ModuleReleaseMetaList, moduleName string) []string {
+	channels := []string{}
+	for _, releaseMeta := range releaseMetas.Items {
+		if releaseMeta.Spec.ModuleName == moduleName {
+			for _, assignment := range releaseMeta.Spec.Channels {
+				channels = append(channels, assignment.Channel)
+			}
+		}
+	}
+
+	return channels
+}
+
+// look for state of the module with specified name in the Kyma CR status
+func getModuleState(kymaCR *kyma.Kyma, moduleName string) string {
+	for _, module := range kymaCR.Status.Modules {
+		if module.Name == moduleName {
+			return module.State
+		}
+	}
+
+	return ""
+}
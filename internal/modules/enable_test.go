@@ -0,0 +1,138 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamic_fake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/utils/ptr"
+)
+
+func TestEnable(t *testing.T) {
+	t.Run("add module to the Kyma CR", func(t *testing.T) {
+		client := fixEnableKymaClient()
+
+		clierr := Enable(context.Background(), client, "keda", "regular", true)
+		require.Nil(t, clierr)
+
+		kymaCR, err := client.GetDefaultKyma(context.Background())
+		require.NoError(t, err)
+		require.Contains(t, kymaCR.Spec.Modules, kyma.Module{
+			Name:                 "keda",
+			Channel:              "regular",
+			CustomResourcePolicy: kyma.CustomResourcePolicyCreateAndDelete,
+			Managed:              ptr.To(true),
+		})
+	})
+
+	t.Run("add module without default CR", func(t *testing.T) {
+		client := fixEnableKymaClient()
+
+		clierr := Enable(context.Background(), client, "serverless", "", false)
+		require.Nil(t, clierr)
+
+		kymaCR, err := client.GetDefaultKyma(context.Background())
+		require.NoError(t, err)
+		require.Contains(t, kymaCR.Spec.Modules, kyma.Module{
+			Name:                 "serverless",
+			CustomResourcePolicy: kyma.CustomResourcePolicyIgnore,
+			Managed:              ptr.To(false),
+		})
+	})
+
+	t.Run("module not available", func(t *testing.T) {
+		client := fixEnableKymaClient()
+
+		clierr := Enable(context.Background(), client, "unknown", "", false)
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "module unknown is not available on the cluster")
+	})
+
+	t.Run("channel not available", func(t *testing.T) {
+		client := fixEnableKymaClient()
+
+		clierr := Enable(context.Background(), client, "keda", "experimental", false)
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "channel experimental is not available for the keda module")
+		require.Contains(t, clierr.String(), "Use one of the available channels: regular, fast")
+	})
+}
+
+func TestWaitForModuleState(t *testing.T) {
+	t.Run("module is ready", func(t *testing.T) {
+		client := fixKymaClientWithModuleState("Ready")
+		buffer := bytes.NewBuffer([]byte{})
+
+		clierr := WaitForModuleState(context.Background(), client, buffer, "keda", time.Millisecond)
+		require.Nil(t, clierr)
+		require.Equal(t, "module keda is in the Ready state\n", buffer.String())
+	})
+
+	t.Run("module is in the error state", func(t *testing.T) {
+		client := fixKymaClientWithModuleState("Error")
+		buffer := bytes.NewBuffer([]byte{})
+
+		clierr := WaitForModuleState(context.Background(), client, buffer, "keda", time.Millisecond)
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "failed to wait for the keda module to be ready")
+		require.Equal(t, "module keda is in the Error state\n", buffer.String())
+	})
+
+	t.Run("context timeout", func(t *testing.T) {
+		client := fixKymaClientWithModuleState("Processing")
+		buffer := bytes.NewBuffer([]byte{})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		clierr := WaitForModuleState(ctx, client, buffer, "keda", time.Millisecond)
+		require.NotNil(t, clierr)
+		require.Equal(t, "module keda is in the Processing state\n", buffer.String())
+	})
+}
+
+func fixEnableKymaClient() kyma.Interface {
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypes(kyma.GVRModuleTemplate.GroupVersion())
+	scheme.AddKnownTypes(kyma.GVRModuleReleaseMeta.GroupVersion())
+	scheme.AddKnownTypes(kyma.GVRKyma.GroupVersion())
+	return kyma.NewClient(dynamic_fake.NewSimpleDynamicClient(scheme,
+		&testModuleTemplate1,
+		&testModuleTemplate2,
+		&testModuleTemplate3,
+		&testModuleTemplate4,
+		&testReleaseMeta1,
+		&testReleaseMeta2,
+		&testKymaCR,
+	))
+}
+
+func fixKymaClientWithModuleState(state string) kyma.Interface {
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypes(kyma.GVRKyma.GroupVersion())
+	return kyma.NewClient(dynamic_fake.NewSimpleDynamicClient(scheme,
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "operator.kyma-project.io/v1beta2",
+				"kind":       "Kyma",
+				"metadata": map[string]interface{}{
+					"name":      kyma.DefaultKymaName,
+					"namespace": kyma.DefaultKymaNamespace,
+				},
+				"status": map[string]interface{}{
+					"modules": []interface{}{
+						map[string]interface{}{
+							"name":  "keda",
+							"state": state,
+						},
+					},
+				},
+			},
+		},
+	))
+}
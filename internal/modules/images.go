@@ -0,0 +1,193 @@
+package modules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ModuleImage describes a container image delivered with the module version
+type ModuleImage struct {
+	Name             string `json:"name" yaml:"name"`
+	Image            string `json:"image" yaml:"image"`
+	Version          string `json:"version,omitempty" yaml:"version,omitempty"`
+	Digest           string `json:"digest,omitempty" yaml:"digest,omitempty"`
+	SourceRepository string `json:"sourceRepository,omitempty" yaml:"sourceRepository,omitempty"`
+	SourceCommit     string `json:"sourceCommit,omitempty" yaml:"sourceCommit,omitempty"`
+}
+
+// ListImages returns images from the OCM component descriptor of the module version
+// the installed version is used if version is empty and the module is installed
+func ListImages(ctx context.Context, client kyma.Interface, module, version string) ([]ModuleImage, clierror.Error) {
+	if version == "" {
+		defaultKyma, err := client.GetDefaultKyma(ctx)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, clierror.Wrap(err, clierror.New("failed to get the default Kyma CR from the cluster"))
+		}
+
+		if defaultKyma != nil {
+			version = getInstalledVersion(defaultKyma, module)
+		}
+	}
+
+	moduleTemplate, clierr := findModuleTemplateByVersion(ctx, client, module, version)
+	if clierr != nil {
+		return nil, clierr
+	}
+
+	descriptor, err := parseDescriptor(moduleTemplate)
+	if err != nil {
+		return nil, clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to parse the component descriptor of the %s module", module)))
+	}
+
+	return getDescriptorImages(descriptor), nil
+}
+
+// RenderImages renders module images to the stdout in the given format
+func RenderImages(images []ModuleImage, format types.Format) error {
+	return renderImages(os.Stdout, images, format)
+}
+
+func renderImages(writer io.Writer, images []ModuleImage, format types.Format) error {
+	return types.RenderFormat(writer, images, format, func(writer io.Writer) {
+		rows := [][]string{}
+		for _, image := range images {
+			rows = append(rows, []string{image.Name, image.Image, image.Digest, describeSource(image)})
+		}
+
+		types.RenderTable(writer, rows, []string{"NAME", "IMAGE", "DIGEST", "SOURCE"})
+	})
+}
+
+// convert source into the format 'repository@commit'
+func describeSource(image ModuleImage) string {
+	if image.SourceCommit == "" {
+		return image.SourceRepository
+	}
+
+	return fmt.Sprintf("%s@%s", image.SourceRepository, image.SourceCommit)
+}
+
+// component descriptor in the OCM v2 schema
+type componentDescriptor struct {
+	Meta struct {
+		SchemaVersion string `json:"schemaVersion"`
+	} `json:"meta"`
+	Component struct {
+		Name      string              `json:"name"`
+		Version   string              `json:"version"`
+		Resources []componentResource `json:"resources"`
+		Sources   []componentSource   `json:"sources"`
+	} `json:"component"`
+}
+
+type componentResource struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Type    string `json:"type"`
+	Access  struct {
+		Type           string `json:"type"`
+		ImageReference string `json:"imageReference"`
+	} `json:"access"`
+	Digest *struct {
+		HashAlgorithm string `json:"hashAlgorithm"`
+		Value         string `json:"value"`
+	} `json:"digest,omitempty"`
+	SrcRefs []struct {
+		IdentitySelector map[string]string `json:"identitySelector"`
+	} `json:"srcRefs,omitempty"`
+}
+
+type componentSource struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Access  struct {
+		Type    string `json:"type"`
+		RepoURL string `json:"repoUrl"`
+		Commit  string `json:"commit"`
+	} `json:"access"`
+}
+
+func parseDescriptor(moduleTemplate *kyma.ModuleTemplate) (*componentDescriptor, error) {
+	raw := moduleTemplate.Spec.Descriptor.Raw
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("ModuleTemplate %s has no descriptor", moduleTemplate.GetName())
+	}
+
+	descriptor := &componentDescriptor{}
+	err := json.Unmarshal(raw, descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	if descriptor.Meta.SchemaVersion != "v2" {
+		return nil, fmt.Errorf("unsupported descriptor schema version '%s'", descriptor.Meta.SchemaVersion)
+	}
+
+	return descriptor, nil
+}
+
+// getDescriptorImages returns resources referencing container images
+// the source is taken from the resource srcRefs or from the only source of the component
+func getDescriptorImages(descriptor *componentDescriptor) []ModuleImage {
+	images := []ModuleImage{}
+	for _, resource := range descriptor.Component.Resources {
+		if resource.Access.ImageReference == "" {
+			continue
+		}
+
+		image := ModuleImage{
+			Name:    resource.Name,
+			Image:   resource.Access.ImageReference,
+			Version: resource.Version,
+			Digest:  getResourceDigest(resource),
+		}
+
+		if source := findResourceSource(descriptor.Component.Sources, resource); source != nil {
+			image.SourceRepository = source.Access.RepoURL
+			image.SourceCommit = source.Access.Commit
+		}
+
+		images = append(images, image)
+	}
+
+	return images
+}
+
+// getResourceDigest returns digest in the format 'algorithm:value' from the resource digest or the image reference
+func getResourceDigest(resource componentResource) string {
+	if resource.Digest != nil && resource.Digest.Value != "" {
+		algorithm := strings.ToLower(strings.ReplaceAll(resource.Digest.HashAlgorithm, "-", ""))
+		return fmt.Sprintf("%s:%s", algorithm, resource.Digest.Value)
+	}
+
+	if _, digest, found := strings.Cut(resource.Access.ImageReference, "@"); found {
+		return digest
+	}
+
+	return ""
+}
+
+func findResourceSource(sources []componentSource, resource componentResource) *componentSource {
+	for _, srcRef := range resource.SrcRefs {
+		for i := range sources {
+			if sources[i].Name == srcRef.IdentitySelector["name"] {
+				return &sources[i]
+			}
+		}
+	}
+
+	if len(resource.SrcRefs) == 0 && len(sources) == 1 {
+		return &sources[0]
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamic_fake "k8s.io/client-go/dynamic/fake"
+)
+
+var testServerlessImages = []ModuleImage{
+	{
+		Name:             "function-controller",
+		Image:            "europe-docker.pkg.dev/kyma-project/prod/function-controller:1.2.0",
+		Version:          "1.2.0",
+		Digest:           "sha256:2b9f0f7c",
+		SourceRepository: "https://github.com/kyma-project/serverless",
+		SourceCommit:     "8d2a1c3",
+	},
+	{
+		Name:             "function-buildless-init",
+		Image:            "europe-docker.pkg.dev/kyma-project/prod/function-init:1.2.0@sha256:7c4e1a9d",
+		Version:          "1.2.0",
+		Digest:           "sha256:7c4e1a9d",
+		SourceRepository: "https://github.com/kyma-project/serverless-init",
+	},
+}
+
+func TestListImages(t *testing.T) {
+	t.Run("list images of the installed version", func(t *testing.T) {
+		client := fixImagesKymaClient(true)
+
+		images, clierr := ListImages(context.Background(), client, "serverless", "")
+		require.Nil(t, clierr)
+		require.Equal(t, testServerlessImages, images)
+	})
+
+	t.Run("list images of the given version", func(t *testing.T) {
+		client := fixImagesKymaClient(true)
+
+		images, clierr := ListImages(context.Background(), client, "serverless", "1.1.0")
+		require.Nil(t, clierr)
+		require.Equal(t, []ModuleImage{}, images)
+	})
+
+	t.Run("version is required if module is not installed", func(t *testing.T) {
+		client := fixImagesKymaClient(false)
+
+		_, clierr := ListImages(context.Background(), client, "serverless", "")
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "found more than one version of the serverless module")
+	})
+
+	t.Run("unsupported descriptor", func(t *testing.T) {
+		client := fixImagesKymaClient(false)
+
+		_, clierr := ListImages(context.Background(), client, "serverless", "1.0.0")
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "failed to parse the component descriptor of the serverless module")
+		require.Contains(t, clierr.String(), "unsupported descriptor schema version 'v3'")
+	})
+}
+
+func Test_renderImages(t *testing.T) {
+	t.Run("render table", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+
+		err := renderImages(buffer, testServerlessImages, types.DefaultFormat)
+		require.NoError(t, err)
+		require.Contains(t, buffer.String(), "NAME")
+		require.Contains(t, buffer.String(), "https://github.com/kyma-project/serverless@8d2a1c3")
+		require.Contains(t, buffer.String(), "sha256:7c4e1a9d")
+	})
+
+	t.Run("render yaml", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+
+		err := renderImages(buffer, testServerlessImages[1:], types.YAMLFormat)
+		require.NoError(t, err)
+		require.Equal(t, `- name: function-buildless-init
+  image: europe-docker.pkg.dev/kyma-project/prod/function-init:1.2.0@sha256:7c4e1a9d
+  version: 1.2.0
+  digest: sha256:7c4e1a9d
+  sourceRepository: https://github.com/kyma-project/serverless-init
+`, buffer.String())
+	})
+}
+
+func fixImagesKymaClient(installed bool) kyma.Interface {
+	objs := []runtime.Object{
+		fixImagesModuleTemplate("1.2.0", map[string]interface{}{
+			"meta": map[string]interface{}{
+				"schemaVersion": "v2",
+			},
+			"component": map[string]interface{}{
+				"name":    "kyma-project.io/module/serverless",
+				"version": "1.2.0",
+				"resources": []interface{}{
+					map[string]interface{}{
+						"name":    "function-controller",
+						"version": "1.2.0",
+						"type":    "ociArtifact",
+						"access": map[string]interface{}{
+							"type":           "ociArtifact",
+							"imageReference": "europe-docker.pkg.dev/kyma-project/prod/function-controller:1.2.0",
+						},
+						"digest": map[string]interface{}{
+							"hashAlgorithm":          "SHA-256",
+							"normalisationAlgorithm": "ociArtifactDigest/v1",
+							"value":                  "2b9f0f7c",
+						},
+						"srcRefs": []interface{}{
+							map[string]interface{}{
+								"identitySelector": map[string]interface{}{
+									"name": "module-sources",
+								},
+							},
+						},
+					},
+					map[string]interface{}{
+						"name":    "function-buildless-init",
+						"version": "1.2.0",
+						"type":    "ociImage",
+						"access": map[string]interface{}{
+							"type":           "ociRegistry",
+							"imageReference": "europe-docker.pkg.dev/kyma-project/prod/function-init:1.2.0@sha256:7c4e1a9d",
+						},
+						"srcRefs": []interface{}{
+							map[string]interface{}{
+								"identitySelector": map[string]interface{}{
+									"name": "init-sources",
+								},
+							},
+						},
+					},
+					map[string]interface{}{
+						"name": "raw-manifest",
+						"type": "yaml",
+						"access": map[string]interface{}{
+							"type":           "localBlob",
+							"localReference": "sha256:0a1b2c",
+						},
+					},
+				},
+				"sources": []interface{}{
+					map[string]interface{}{
+						"name": "module-sources",
+						"access": map[string]interface{}{
+							"type":    "gitHub",
+							"repoUrl": "https://github.com/kyma-project/serverless",
+							"commit":  "8d2a1c3",
+						},
+					},
+					map[string]interface{}{
+						"name": "init-sources",
+						"access": map[string]interface{}{
+							"type":    "gitHub",
+							"repoUrl": "https://github.com/kyma-project/serverless-init",
+						},
+					},
+				},
+			},
+		}),
+		fixImagesModuleTemplate("1.1.0", map[string]interface{}{
+			"meta": map[string]interface{}{
+				"schemaVersion": "v2",
+			},
+			"component": map[string]interface{}{
+				"name":    "kyma-project.io/module/serverless",
+				"version": "1.1.0",
+			},
+		}),
+		fixImagesModuleTemplate("1.0.0", map[string]interface{}{
+			"meta": map[string]interface{}{
+				"schemaVersion": "v3",
+			},
+		}),
+	}
+
+	if installed {
+		objs = append(objs, &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "operator.kyma-project.io/v1beta2",
+				"kind":       "Kyma",
+				"metadata": map[string]interface{}{
+					"name":      kyma.DefaultKymaName,
+					"namespace": kyma.DefaultKymaNamespace,
+				},
+				"status": map[string]interface{}{
+					"modules": []interface{}{
+						map[string]interface{}{
+							"name":    "serverless",
+							"version": "1.2.0",
+						},
+					},
+				},
+			},
+		})
+	}
+
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypes(kyma.GVRModuleTemplate.GroupVersion())
+	return kyma.NewClient(dynamic_fake.NewSimpleDynamicClient(scheme, objs...))
+}
+
+func fixImagesModuleTemplate(version string, descriptor map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "operator.kyma-project.io/v1beta2",
+			"kind":       "ModuleTemplate",
+			"metadata": map[string]interface{}{
+				"name":      "serverless-" + version,
+				"namespace": "kyma-system",
+			},
+			"spec": map[string]interface{}{
+				"moduleName": "serverless",
+				"version":    version,
+				"descriptor": descriptor,
+			},
+		},
+	}
+}
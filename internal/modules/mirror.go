@@ -0,0 +1,354 @@
+package modules
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type MirrorOptions struct {
+	// Registry is the address of the target registry with an optional path, for example 'registry.local:5000/kyma'
+	Registry string
+	// Modules limits mirrored modules, all modules are mirrored if empty
+	Modules []string
+	// Insecure allows using the source and target registries over http
+	Insecure bool
+}
+
+var errNoDescriptor = errors.New("ModuleTemplate has no descriptor")
+
+// Mirror copies images, component descriptors and resources of modules to the registry
+// and returns ModuleTemplates rewritten to reference the mirrored ones
+// ModuleTemplates without the descriptor are skipped, progress and warnings are written to the writer
+func Mirror(ctx context.Context, client kyma.Interface, writer io.Writer, opts MirrorOptions) ([]unstructured.Unstructured, clierror.Error) {
+	moduleTemplates, err := client.ListModuleTemplate(ctx)
+	if err != nil {
+		return nil, clierror.Wrap(err, clierror.New("failed to list available modules from the cluster"))
+	}
+
+	mirrored := []unstructured.Unstructured{}
+	copied := []string{}
+	copiedResources := map[string]string{}
+	for _, moduleTemplate := range moduleTemplates.Items {
+		if len(opts.Modules) > 0 && !slices.Contains(opts.Modules, moduleTemplate.Spec.ModuleName) {
+			continue
+		}
+
+		moduleName := fmt.Sprintf("%s %s", moduleTemplate.Spec.ModuleName, moduleTemplate.Spec.Version)
+		artifacts, err := mirrorDescriptor(&moduleTemplate, opts)
+		if errors.Is(err, errNoDescriptor) {
+			fmt.Fprintf(writer, "warning: skipping the %s module, its ModuleTemplate %s has no descriptor\n", moduleName, moduleTemplate.GetName())
+			continue
+		}
+		if err != nil {
+			return nil, clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to rewrite the component descriptor of the %s module", moduleName)))
+		}
+
+		for _, artifact := range artifacts {
+			if slices.Contains(copied, artifact.source) {
+				continue
+			}
+
+			fmt.Fprintf(writer, "mirroring %s to %s\n", artifact.source, artifact.target)
+			err = copyArtifact(ctx, artifact, opts)
+			if err != nil {
+				return nil, clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to mirror %s", artifact.source),
+					"Make sure you are logged in to the source and target registries",
+					"Use the --insecure flag if registries are available over http only"))
+			}
+			copied = append(copied, artifact.source)
+		}
+
+		for i, resource := range moduleTemplate.Spec.Resources {
+			link, ok := copiedResources[resource.Link]
+			if !ok {
+				// repository names must be lowercase
+				target := strings.ToLower(fmt.Sprintf("%s/resources/%s/%s", opts.Registry, moduleTemplate.Spec.ModuleName, resource.Name)) + ":" + moduleTemplate.Spec.Version
+				fmt.Fprintf(writer, "mirroring %s to %s\n", resource.Link, target)
+				link, err = pushResource(ctx, resource.Link, target, opts)
+				if err != nil {
+					return nil, clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to mirror the %s resource of the %s module", resource.Name, moduleName),
+						"Make sure the resource link is available and you are logged in to the target registry",
+						"Use the --insecure flag if the target registry is available over http only"))
+				}
+				copiedResources[resource.Link] = link
+			}
+
+			moduleTemplate.Spec.Resources[i].Link = link
+		}
+
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&moduleTemplate)
+		if err != nil {
+			return nil, clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to convert ModuleTemplate of the %s module", moduleName)))
+		}
+		mirrored = append(mirrored, *sanitizeResource(&unstructured.Unstructured{Object: obj}))
+	}
+
+	return mirrored, nil
+}
+
+// sanitizeResource returns copy of the resource without fields managed by the server
+// so it can be applied to another cluster
+func sanitizeResource(resource *unstructured.Unstructured) *unstructured.Unstructured {
+	obj := resource.DeepCopy()
+	unstructured.RemoveNestedField(obj.Object, "status")
+	for _, field := range []string{"managedFields", "resourceVersion", "uid", "generation", "creationTimestamp", "selfLink"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+
+	return obj
+}
+
+// RenderModuleTemplates writes ModuleTemplates as yaml documents
+func RenderModuleTemplates(writer io.Writer, moduleTemplates []unstructured.Unstructured) error {
+	encoder := yaml.NewEncoder(writer)
+	encoder.SetIndent(2)
+	defer encoder.Close()
+
+	for _, moduleTemplate := range moduleTemplates {
+		err := encoder.Encode(moduleTemplate.Object)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SaveModuleTemplates writes every ModuleTemplate to the '<name>.yaml' file in the directory
+func SaveModuleTemplates(dir string, moduleTemplates []unstructured.Unstructured) error {
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	for _, moduleTemplate := range moduleTemplates {
+		file, err := os.Create(filepath.Join(dir, moduleTemplate.GetName()+".yaml"))
+		if err != nil {
+			return err
+		}
+
+		err = RenderModuleTemplates(file, []unstructured.Unstructured{moduleTemplate})
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type mirrorArtifact struct {
+	source string
+	target string
+}
+
+// mirrorDescriptor rewrites image references in the ModuleTemplate descriptor to the target registry
+// and adds the registry as the last repository context so the component descriptor is resolved from it
+// it returns artifacts that must be copied to the target registry
+func mirrorDescriptor(moduleTemplate *kyma.ModuleTemplate, opts MirrorOptions) ([]mirrorArtifact, error) {
+	if len(moduleTemplate.Spec.Descriptor.Raw) == 0 {
+		return nil, errNoDescriptor
+	}
+
+	descriptor := map[string]interface{}{}
+	err := json.Unmarshal(moduleTemplate.Spec.Descriptor.Raw, &descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaVersion, _, _ := unstructured.NestedString(descriptor, "meta", "schemaVersion")
+	if schemaVersion != "v2" {
+		return nil, fmt.Errorf("unsupported descriptor schema version '%s'", schemaVersion)
+	}
+
+	artifacts := []mirrorArtifact{}
+	componentName, _, _ := unstructured.NestedString(descriptor, "component", "name")
+	componentVersion, _, _ := unstructured.NestedString(descriptor, "component", "version")
+	repositoryContexts, _, _ := unstructured.NestedSlice(descriptor, "component", "repositoryContexts")
+	if len(repositoryContexts) > 0 {
+		// the last repository context is the one where the component descriptor is stored
+		baseURL, _, _ := unstructured.NestedString(repositoryContexts[len(repositoryContexts)-1].(map[string]interface{}), "baseUrl")
+		artifacts = append(artifacts, mirrorArtifact{
+			source: fmt.Sprintf("%s/component-descriptors/%s:%s", baseURL, componentName, componentVersion),
+			target: fmt.Sprintf("%s/component-descriptors/%s:%s", opts.Registry, componentName, componentVersion),
+		})
+	}
+
+	resources, _, _ := unstructured.NestedSlice(descriptor, "component", "resources")
+	for _, resource := range resources {
+		resourceMap, ok := resource.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		imageReference, _, _ := unstructured.NestedString(resourceMap, "access", "imageReference")
+		if imageReference == "" {
+			continue
+		}
+
+		target, err := mirrorReference(imageReference, opts.Registry)
+		if err != nil {
+			return nil, err
+		}
+
+		artifacts = append(artifacts, mirrorArtifact{
+			source: imageReference,
+			target: target,
+		})
+		_ = unstructured.SetNestedField(resourceMap, target, "access", "imageReference")
+	}
+
+	_ = unstructured.SetNestedSlice(descriptor, resources, "component", "resources")
+	_ = unstructured.SetNestedSlice(descriptor, append(repositoryContexts, map[string]interface{}{
+		"type":                 "OCIRegistry",
+		"baseUrl":              opts.Registry,
+		"componentNameMapping": "urlPath",
+	}), "component", "repositoryContexts")
+
+	moduleTemplate.Spec.Descriptor.Raw, err = json.Marshal(descriptor)
+	moduleTemplate.Spec.Descriptor.Object = nil
+	return artifacts, err
+}
+
+// mirrorReference replaces registry of the image with the target one
+// the repository path, tag and digest are kept
+func mirrorReference(image, registry string) (string, error) {
+	base, digest, hasDigest := strings.Cut(image, "@")
+	tag, err := name.NewTag(base, name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+
+	target := fmt.Sprintf("%s/%s", registry, tag.RepositoryStr())
+	if hasTag(base) {
+		target += ":" + tag.TagStr()
+	}
+	if hasDigest {
+		target += "@" + digest
+	}
+
+	return target, nil
+}
+
+// check if the last segment of the reference contains a tag
+func hasTag(reference string) bool {
+	segments := strings.Split(reference, "/")
+	return strings.Contains(segments[len(segments)-1], ":")
+}
+
+// copyArtifact copies the image or the image index from the source to the target
+// the target is written by tag if it has one so the tag is preserved in the target registry
+func copyArtifact(ctx context.Context, artifact mirrorArtifact, opts MirrorOptions) error {
+	remoteOpts := []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(authn.DefaultKeychain),
+	}
+
+	nameOpts := referenceOptions(opts)
+	source, err := name.ParseReference(artifact.source, nameOpts...)
+	if err != nil {
+		return err
+	}
+
+	targetBase, _, _ := strings.Cut(artifact.target, "@")
+	var target name.Reference
+	if hasTag(targetBase) {
+		target, err = name.NewTag(targetBase, nameOpts...)
+	} else {
+		target, err = name.ParseReference(artifact.target, nameOpts...)
+	}
+	if err != nil {
+		return err
+	}
+
+	descriptor, err := remote.Get(source, remoteOpts...)
+	if err != nil {
+		return err
+	}
+
+	if descriptor.MediaType.IsIndex() {
+		index, err := descriptor.ImageIndex()
+		if err != nil {
+			return err
+		}
+
+		return remote.WriteIndex(target, index, remoteOpts...)
+	}
+
+	image, err := descriptor.Image()
+	if err != nil {
+		return err
+	}
+
+	return remote.Write(target, image, remoteOpts...)
+}
+
+// pushResource pushes content of the resource link to the target as a single layer artifact
+// and returns link to the layer blob so the resource can be downloaded from the registry over http
+func pushResource(ctx context.Context, link, target string, opts MirrorOptions) (string, error) {
+	reader, err := openLink(ctx, link)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	tag, err := name.NewTag(target, referenceOptions(opts)...)
+	if err != nil {
+		return "", err
+	}
+
+	layer := static.NewLayer(content, types.MediaType("application/x-yaml"))
+	image, err := mutate.AppendLayers(empty.Image, layer)
+	if err != nil {
+		return "", err
+	}
+
+	err = remote.Write(tag, mutate.MediaType(image, types.OCIManifestSchema1),
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(authn.DefaultKeychain),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := layer.Digest()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s://%s/v2/%s/blobs/%s", tag.Registry.Scheme(), tag.RegistryStr(), tag.RepositoryStr(), digest), nil
+}
+
+func referenceOptions(opts MirrorOptions) []name.Option {
+	nameOpts := []name.Option{name.WeakValidation}
+	if opts.Insecure {
+		nameOpts = append(nameOpts, name.Insecure)
+	}
+
+	return nameOpts
+}
@@ -0,0 +1,291 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamic_fake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestMirror(t *testing.T) {
+	source := httptest.NewServer(registry.New())
+	defer source.Close()
+	target := httptest.NewServer(registry.New())
+	defer target.Close()
+
+	sourceHost := strings.TrimPrefix(source.URL, "http://")
+	targetHost := strings.TrimPrefix(target.URL, "http://")
+
+	controllerImage := fmt.Sprintf("%s/prod/function-controller:1.2.0", sourceHost)
+	controllerDigest := pushRandomImage(t, controllerImage)
+	initImage := fmt.Sprintf("%s/prod/function-init@%s", sourceHost, pushRandomImage(t, sourceHost+"/prod/function-init:1.2.0"))
+	pushRandomImage(t, sourceHost+"/component-descriptors/kyma-project.io/module/serverless:1.2.0")
+
+	manifest := "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: serverless\n"
+	manifestServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(manifest))
+	}))
+	defer manifestServer.Close()
+	manifestLink := manifestServer.URL + "/manager.yaml"
+
+	t.Run("mirror module", func(t *testing.T) {
+		client := fixMirrorKymaClient(sourceHost, controllerImage, initImage, manifestLink)
+		progress := bytes.NewBuffer([]byte{})
+
+		moduleTemplates, clierr := Mirror(context.Background(), client, progress, MirrorOptions{
+			Registry: targetHost + "/mirror",
+			Modules:  []string{"serverless"},
+		})
+		require.Nil(t, clierr)
+		require.Len(t, moduleTemplates, 1)
+		require.Contains(t, progress.String(), fmt.Sprintf("mirroring %s to %s/mirror/prod/function-controller:1.2.0", controllerImage, targetHost))
+
+		mirroredController := fmt.Sprintf("%s/mirror/prod/function-controller:1.2.0", targetHost)
+		require.Equal(t, controllerDigest, getRemoteDigest(t, mirroredController))
+		mirroredInit := fmt.Sprintf("%s/mirror/prod/function-init@%s", targetHost, strings.Split(initImage, "@")[1])
+		getRemoteDigest(t, mirroredInit)
+		getRemoteDigest(t, targetHost+"/mirror/component-descriptors/kyma-project.io/module/serverless:1.2.0")
+
+		resources, _, _ := unstructured.NestedSlice(moduleTemplates[0].Object, "spec", "descriptor", "component", "resources")
+		require.Len(t, resources, 2)
+		reference, _, _ := unstructured.NestedString(resources[0].(map[string]interface{}), "access", "imageReference")
+		require.Equal(t, mirroredController, reference)
+		reference, _, _ = unstructured.NestedString(resources[1].(map[string]interface{}), "access", "imageReference")
+		require.Equal(t, mirroredInit, reference)
+
+		repositoryContexts, _, _ := unstructured.NestedSlice(moduleTemplates[0].Object, "spec", "descriptor", "component", "repositoryContexts")
+		require.Equal(t, []interface{}{
+			map[string]interface{}{
+				"type":                 "OCIRegistry",
+				"baseUrl":              sourceHost,
+				"componentNameMapping": "urlPath",
+			},
+			map[string]interface{}{
+				"type":                 "OCIRegistry",
+				"baseUrl":              targetHost + "/mirror",
+				"componentNameMapping": "urlPath",
+			},
+		}, repositoryContexts)
+
+		_, found, _ := unstructured.NestedString(moduleTemplates[0].Object, "metadata", "resourceVersion")
+		require.False(t, found)
+
+		moduleResources, _, _ := unstructured.NestedSlice(moduleTemplates[0].Object, "spec", "resources")
+		require.Len(t, moduleResources, 1)
+		link, _, _ := unstructured.NestedString(moduleResources[0].(map[string]interface{}), "link")
+		require.True(t, strings.HasPrefix(link, fmt.Sprintf("http://%s/v2/mirror/resources/serverless/rawmanifest/blobs/sha256:", targetHost)))
+		require.Contains(t, progress.String(), fmt.Sprintf("mirroring %s to %s/mirror/resources/serverless/rawmanifest:1.2.0", manifestLink, targetHost))
+
+		resp, err := http.Get(link)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		content, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, manifest, string(content))
+	})
+
+	t.Run("skip module without descriptor", func(t *testing.T) {
+		moduleTemplate := fixImagesModuleTemplate("1.0.0", nil)
+		scheme := runtime.NewScheme()
+		scheme.AddKnownTypes(kyma.GVRModuleTemplate.GroupVersion())
+		client := kyma.NewClient(dynamic_fake.NewSimpleDynamicClient(scheme, moduleTemplate))
+		progress := bytes.NewBuffer([]byte{})
+
+		moduleTemplates, clierr := Mirror(context.Background(), client, progress, MirrorOptions{
+			Registry: targetHost,
+		})
+		require.Nil(t, clierr)
+		require.Empty(t, moduleTemplates)
+		require.Contains(t, progress.String(), "warning: skipping the serverless 1.0.0 module, its ModuleTemplate serverless-1.0.0 has no descriptor")
+	})
+
+	t.Run("skip not selected modules", func(t *testing.T) {
+		client := fixMirrorKymaClient(sourceHost, controllerImage, initImage, manifestLink)
+
+		moduleTemplates, clierr := Mirror(context.Background(), client, bytes.NewBuffer([]byte{}), MirrorOptions{
+			Registry: targetHost,
+			Modules:  []string{"istio"},
+		})
+		require.Nil(t, clierr)
+		require.Empty(t, moduleTemplates)
+	})
+
+	t.Run("missing image", func(t *testing.T) {
+		client := fixMirrorKymaClient(sourceHost, sourceHost+"/prod/missing:1.0.0", initImage, manifestLink)
+
+		_, clierr := Mirror(context.Background(), client, bytes.NewBuffer([]byte{}), MirrorOptions{
+			Registry: targetHost,
+		})
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), fmt.Sprintf("failed to mirror %s/prod/missing:1.0.0", sourceHost))
+	})
+}
+
+func Test_mirrorReference(t *testing.T) {
+	tests := []struct {
+		name  string
+		image string
+		want  string
+	}{
+		{
+			name:  "tag",
+			image: "europe-docker.pkg.dev/kyma-project/prod/function-controller:1.2.0",
+			want:  "registry.local:5000/kyma/kyma-project/prod/function-controller:1.2.0",
+		},
+		{
+			name:  "digest",
+			image: "europe-docker.pkg.dev/kyma-project/prod/function-init@sha256:7c4e1a9d",
+			want:  "registry.local:5000/kyma/kyma-project/prod/function-init@sha256:7c4e1a9d",
+		},
+		{
+			name:  "tag and digest",
+			image: "europe-docker.pkg.dev/kyma-project/prod/function-init:1.2.0@sha256:7c4e1a9d",
+			want:  "registry.local:5000/kyma/kyma-project/prod/function-init:1.2.0@sha256:7c4e1a9d",
+		},
+		{
+			name:  "docker hub",
+			image: "nginx:1.27",
+			want:  "registry.local:5000/kyma/library/nginx:1.27",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mirrorReference(tt.image, "registry.local:5000/kyma")
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_sanitizeResource(t *testing.T) {
+	resource := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "operator.kyma-project.io/v1beta2",
+			"kind":       "ModuleTemplate",
+			"metadata": map[string]interface{}{
+				"name":              "serverless-1.2.0",
+				"namespace":         "kyma-system",
+				"labels":            map[string]interface{}{"app": "serverless"},
+				"managedFields":     []interface{}{map[string]interface{}{"manager": "cli"}},
+				"resourceVersion":   "123",
+				"uid":               "abc",
+				"generation":        int64(2),
+				"creationTimestamp": "2024-01-01T12:00:00Z",
+			},
+			"spec":   map[string]interface{}{"moduleName": "serverless"},
+			"status": map[string]interface{}{"state": "Ready"},
+		},
+	}
+
+	sanitized := sanitizeResource(resource)
+	require.Equal(t, map[string]interface{}{
+		"apiVersion": "operator.kyma-project.io/v1beta2",
+		"kind":       "ModuleTemplate",
+		"metadata": map[string]interface{}{
+			"name":      "serverless-1.2.0",
+			"namespace": "kyma-system",
+			"labels":    map[string]interface{}{"app": "serverless"},
+		},
+		"spec": map[string]interface{}{"moduleName": "serverless"},
+	}, sanitized.Object)
+
+	// given resource is not modified
+	require.Contains(t, resource.Object, "status")
+	require.Equal(t, "123", resource.GetResourceVersion())
+}
+
+func TestRenderModuleTemplates(t *testing.T) {
+	buffer := bytes.NewBuffer([]byte{})
+
+	err := RenderModuleTemplates(buffer, []unstructured.Unstructured{
+		*fixImagesModuleTemplate("1.0.0", map[string]interface{}{}),
+		*fixImagesModuleTemplate("1.1.0", map[string]interface{}{}),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, strings.Count(buffer.String(), "---\n"))
+	require.Contains(t, buffer.String(), "name: serverless-1.0.0")
+	require.Contains(t, buffer.String(), "name: serverless-1.1.0")
+}
+
+func fixMirrorKymaClient(sourceHost, controllerImage, initImage, manifestLink string) kyma.Interface {
+	moduleTemplate := fixImagesModuleTemplate("1.2.0", map[string]interface{}{
+		"meta": map[string]interface{}{
+			"schemaVersion": "v2",
+		},
+		"component": map[string]interface{}{
+			"name":    "kyma-project.io/module/serverless",
+			"version": "1.2.0",
+			"repositoryContexts": []interface{}{
+				map[string]interface{}{
+					"type":                 "OCIRegistry",
+					"baseUrl":              sourceHost,
+					"componentNameMapping": "urlPath",
+				},
+			},
+			"resources": []interface{}{
+				map[string]interface{}{
+					"name": "function-controller",
+					"access": map[string]interface{}{
+						"type":           "ociArtifact",
+						"imageReference": controllerImage,
+					},
+				},
+				map[string]interface{}{
+					"name": "function-buildless-init",
+					"access": map[string]interface{}{
+						"type":           "ociArtifact",
+						"imageReference": initImage,
+					},
+				},
+			},
+		},
+	})
+	moduleTemplate.SetResourceVersion("123")
+	_ = unstructured.SetNestedSlice(moduleTemplate.Object, []interface{}{
+		map[string]interface{}{
+			"name": "rawManifest",
+			"link": manifestLink,
+		},
+	}, "spec", "resources")
+
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypes(kyma.GVRModuleTemplate.GroupVersion())
+	return kyma.NewClient(dynamic_fake.NewSimpleDynamicClient(scheme, moduleTemplate))
+}
+
+func pushRandomImage(t *testing.T, image string) string {
+	tag, err := name.NewTag(image)
+	require.NoError(t, err)
+
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(tag, img))
+
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	return digest.String()
+}
+
+func getRemoteDigest(t *testing.T, image string) string {
+	ref, err := name.ParseReference(image)
+	require.NoError(t, err)
+
+	desc, err := remote.Get(ref)
+	require.NoError(t, err)
+
+	return desc.Digest.String()
+}
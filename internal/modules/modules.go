@@ -4,7 +4,9 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/kyma-project/cli.v3/internal/kube"
 	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"golang.org/x/sync/errgroup"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
@@ -25,6 +27,7 @@ type ModuleInstallDetails struct {
 	Version string
 	Channel string
 	Managed Managed
+	State   string
 }
 
 type ModuleVersion struct {
@@ -35,35 +38,82 @@ type ModuleVersion struct {
 
 type ModulesList []Module
 
-func List(ctx context.Context, client kyma.Interface) (ModulesList, error) {
-	moduleTemplates, err := client.ListModuleTemplate(ctx)
+// ListOptions narrows the modules list
+type ListOptions struct {
+	// Channel limits module versions to those assigned to the channel
+	Channel string
+	// ModuleNames limits modules to those with given names
+	ModuleNames []string
+}
+
+// List returns modules available on the cluster with details of installed ones
+// ModuleTemplates, ModuleReleaseMetas and the default Kyma CR are fetched concurrently
+func List(ctx context.Context, client kube.Client, opts ListOptions) (ModulesList, error) {
+	data, err := loadModulesData(ctx, client, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	modulereleasemetas, err := client.ListModuleReleaseMeta(ctx)
+	return buildModulesList(ctx, client, data, opts), nil
+}
+
+// modulesData contains cluster resources the modules list is built from
+type modulesData struct {
+	moduleTemplates *kyma.ModuleTemplateList
+	releaseMetas    *kyma.ModuleReleaseMetaList
+	defaultKyma     *kyma.Kyma
+}
+
+// loadModulesData fetches ModuleTemplates, ModuleReleaseMetas and the default Kyma CR concurrently
+// the default Kyma CR is nil if it doesn't exist
+func loadModulesData(ctx context.Context, client kube.Client, opts ListOptions) (*modulesData, error) {
+	data := &modulesData{}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.Go(func() (err error) {
+		data.moduleTemplates, err = client.Kyma().ListModuleTemplate(groupCtx, kyma.ByModuleName(opts.ModuleNames...))
+		return err
+	})
+	group.Go(func() (err error) {
+		data.releaseMetas, err = client.Kyma().ListModuleReleaseMeta(groupCtx, kyma.ByModuleName(opts.ModuleNames...))
+		return err
+	})
+	group.Go(func() (err error) {
+		data.defaultKyma, err = client.Kyma().GetDefaultKyma(groupCtx)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	})
+
+	err := group.Wait()
 	if err != nil {
 		return nil, err
 	}
 
-	defaultKyma, err := client.GetDefaultKyma(ctx)
-	if err != nil && !apierrors.IsNotFound(err) {
-		return nil, err
-	}
+	return data, nil
+}
 
+// buildModulesList builds the modules list from already fetched resources
+// only the state of unmanaged modules is read from their module CRs
+func buildModulesList(ctx context.Context, client kube.Client, data *modulesData, opts ListOptions) ModulesList {
 	modulesList := ModulesList{}
-	for _, moduleTemplate := range moduleTemplates.Items {
+	for _, moduleTemplate := range data.moduleTemplates.Items {
 		moduleName := moduleTemplate.Spec.ModuleName
 		version := ModuleVersion{
 			Version:    moduleTemplate.Spec.Version,
 			Repository: moduleTemplate.Spec.Info.Repository,
 			Channel: getAssignedChannel(
-				*modulereleasemetas,
+				*data.releaseMetas,
 				moduleName,
 				moduleTemplate.Spec.Version,
 			),
 		}
 
+		if opts.Channel != "" && version.Channel != opts.Channel {
+			continue
+		}
+
 		if i := getModuleIndex(modulesList, moduleName); i != -1 {
 			// append version if module with same name is in the list
 			modulesList[i].Versions = append(modulesList[i].Versions, version)
@@ -71,7 +121,7 @@ func List(ctx context.Context, client kyma.Interface) (ModulesList, error) {
 			// otherwise create anew record in the list
 			modulesList = append(modulesList, Module{
 				Name:           moduleName,
-				InstallDetails: getInstallDetails(defaultKyma, *modulereleasemetas, moduleName),
+				InstallDetails: getInstallDetails(data.defaultKyma, *data.releaseMetas, moduleName),
 				Versions: []ModuleVersion{
 					version,
 				},
@@ -79,7 +129,19 @@ func List(ctx context.Context, client kyma.Interface) (ModulesList, error) {
 		}
 	}
 
-	return modulesList, nil
+	for i := range modulesList {
+		if modulesList[i].InstallDetails.Managed != ManagedFalse {
+			continue
+		}
+
+		// lifecycle-manager doesn't track state of unmanaged modules so it must be computed from the module CR
+		moduleTemplate := findModuleTemplate(data.moduleTemplates, modulesList[i].Name, modulesList[i].InstallDetails.Version)
+		if moduleTemplate != nil && moduleTemplate.Spec.Manager.Name != "" {
+			modulesList[i].InstallDetails.State = getModuleCRState(ctx, client.RootlessDynamic(), moduleTemplate)
+		}
+	}
+
+	return modulesList
 }
 
 func getInstallDetails(kyma *kyma.Kyma, releaseMetas kyma.ModuleReleaseMetaList, moduleName string) ModuleInstallDetails {
@@ -91,6 +153,7 @@ func getInstallDetails(kyma *kyma.Kyma, releaseMetas kyma.ModuleReleaseMetaList,
 					Channel: getAssignedChannel(releaseMetas, module.Name, moduleVersion),
 					Managed: getManaged(kyma.Spec.Modules, moduleName),
 					Version: moduleVersion,
+					State:   module.State,
 				}
 			}
 		}
@@ -106,7 +169,7 @@ func getInstallDetails(kyma *kyma.Kyma, releaseMetas kyma.ModuleReleaseMetaList,
 func getManaged(specModules []kyma.Module, moduleName string) Managed {
 	for _, module := range specModules {
 		if module.Name == moduleName {
-			return Managed(strconv.FormatBool(module.Managed))
+			return Managed(strconv.FormatBool(module.IsManaged()))
 		}
 	}
 
@@ -133,6 +196,19 @@ func getChannelFromAssignments(assignments []kyma.ChannelVersionAssignment, vers
 	return ""
 }
 
+// look for ModuleTemplate of the module in given version
+// return nil if not exists
+func findModuleTemplate(moduleTemplates *kyma.ModuleTemplateList, moduleName, version string) *kyma.ModuleTemplate {
+	for i := range moduleTemplates.Items {
+		if moduleTemplates.Items[i].Spec.ModuleName == moduleName &&
+			moduleTemplates.Items[i].Spec.Version == version {
+			return &moduleTemplates.Items[i]
+		}
+	}
+
+	return nil
+}
+
 // return index of module with given name. if not exists return -1
 func getModuleIndex(list ModulesList, name string) int {
 	for i := range list {
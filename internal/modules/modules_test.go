@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	kube_fake "github.com/kyma-project/cli.v3/internal/kube/fake"
 	"github.com/kyma-project/cli.v3/internal/kube/kyma"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -151,10 +152,12 @@ var (
 					map[string]interface{}{
 						"name":    "serverless",
 						"version": "0.0.1",
+						"state":   "Unmanaged",
 					},
 					map[string]interface{}{
 						"name":    "keda",
 						"version": "0.2",
+						"state":   "Ready",
 					},
 				},
 			},
@@ -168,6 +171,7 @@ var (
 				Managed: ManagedTrue,
 				Channel: "fast",
 				Version: "0.2",
+				State:   "Ready",
 			},
 			Versions: []ModuleVersion{
 				{
@@ -187,6 +191,7 @@ var (
 				Managed: ManagedFalse,
 				Channel: "fast",
 				Version: "0.0.1",
+				State:   "Unmanaged",
 			},
 			Versions: []ModuleVersion{
 				{
@@ -248,7 +253,9 @@ func TestList(t *testing.T) {
 			&testReleaseMeta2,
 		)
 
-		modules, err := List(context.Background(), kyma.NewClient(dynamicClient))
+		modules, err := List(context.Background(), &kube_fake.FakeKubeClient{
+			TestKymaInterface: kyma.NewClient(dynamicClient),
+		}, ListOptions{})
 
 		require.NoError(t, err)
 		require.Equal(t, ModulesList(testModuleList), modules)
@@ -269,9 +276,46 @@ func TestList(t *testing.T) {
 			&testKymaCR,
 		)
 
-		modules, err := List(context.Background(), kyma.NewClient(dynamicClient))
+		modules, err := List(context.Background(), &kube_fake.FakeKubeClient{
+			TestKymaInterface: kyma.NewClient(dynamicClient),
+		}, ListOptions{})
 
 		require.NoError(t, err)
 		require.Equal(t, ModulesList(testManagedModuleList), modules)
 	})
+
+	t.Run("list modules filtered by name and channel", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		scheme.AddKnownTypes(kyma.GVRModuleTemplate.GroupVersion())
+		scheme.AddKnownTypes(kyma.GVRModuleReleaseMeta.GroupVersion())
+		dynamicClient := dynamic_fake.NewSimpleDynamicClient(scheme,
+			&testModuleTemplate1,
+			&testModuleTemplate2,
+			&testModuleTemplate3,
+			&testModuleTemplate4,
+			&testReleaseMeta1,
+			&testReleaseMeta2,
+		)
+
+		modules, err := List(context.Background(), &kube_fake.FakeKubeClient{
+			TestKymaInterface: kyma.NewClient(dynamicClient),
+		}, ListOptions{
+			Channel:     "fast",
+			ModuleNames: []string{"keda"},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, ModulesList{
+			{
+				Name: "keda",
+				Versions: []ModuleVersion{
+					{
+						Version: "0.2",
+						Channel: "fast",
+					},
+				},
+			},
+		}, modules)
+	})
+
 }
@@ -0,0 +1,441 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/kyma-project/cli.v3/internal/kube"
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+)
+
+// ModuleSet describes the desired set of modules in the default Kyma CR
+type ModuleSet struct {
+	// Channel is the default channel of the Kyma CR, it's not changed if empty
+	Channel string           `yaml:"channel,omitempty"`
+	Modules []ModuleSetEntry `yaml:"modules"`
+}
+
+type ModuleSetEntry struct {
+	Name                 string `yaml:"name"`
+	Channel              string `yaml:"channel,omitempty"`
+	CustomResourcePolicy string `yaml:"customResourcePolicy,omitempty"`
+	// Managed is true if not set
+	Managed *bool `yaml:"managed,omitempty"`
+	// CR overrides fields of the module CR, apiVersion and kind of the default CR are used if not set
+	CR map[string]interface{} `yaml:"cr,omitempty"`
+}
+
+type ModuleSetAction string
+
+const (
+	ModuleSetActionAdd       ModuleSetAction = "add"
+	ModuleSetActionUpdate    ModuleSetAction = "update"
+	ModuleSetActionRemove    ModuleSetAction = "remove"
+	ModuleSetActionConfigure ModuleSetAction = "configure"
+)
+
+type ModuleSetChange struct {
+	Action  ModuleSetAction
+	Module  string
+	Details []string
+}
+
+// ModuleSetPlan contains changes required to reach the desired module set
+type ModuleSetPlan struct {
+	// ChannelChange describes change of the default Kyma CR channel, it's empty if the channel is not changed
+	ChannelChange string
+	Changes       []ModuleSetChange
+
+	moduleSet      *ModuleSet
+	desiredChannel string
+	desiredModules []kyma.Module
+	configs        map[string]map[string]interface{}
+}
+
+// ReadModuleSet reads and validates the module set from the file
+func ReadModuleSet(path string) (*ModuleSet, clierror.Error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to read the %s file", path)))
+	}
+
+	moduleSet := &ModuleSet{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err = decoder.Decode(moduleSet)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to decode the %s file", path),
+			"Make sure the file contains a valid module set"))
+	}
+
+	clierr := validateModuleSet(moduleSet)
+	if clierr != nil {
+		return nil, clierr
+	}
+
+	return moduleSet, nil
+}
+
+func validateModuleSet(moduleSet *ModuleSet) clierror.Error {
+	names := []string{}
+	for i, entry := range moduleSet.Modules {
+		if entry.Name == "" {
+			return clierror.New(fmt.Sprintf("module on position %d has no name", i+1))
+		}
+
+		if slices.Contains(names, entry.Name) {
+			return clierror.New(fmt.Sprintf("module %s is defined more than once", entry.Name))
+		}
+		names = append(names, entry.Name)
+
+		for field := range entry.CR {
+			if !slices.Contains([]string{"apiVersion", "kind", "metadata", "spec"}, field) {
+				return clierror.New(fmt.Sprintf("unsupported field %s in the cr of the %s module", field, entry.Name),
+					"Only spec of the module CR is applied, use the apiVersion, kind, metadata and spec fields")
+			}
+		}
+
+		if entry.CustomResourcePolicy != "" &&
+			entry.CustomResourcePolicy != kyma.CustomResourcePolicyCreateAndDelete &&
+			entry.CustomResourcePolicy != kyma.CustomResourcePolicyIgnore {
+			return clierror.New(fmt.Sprintf("invalid customResourcePolicy '%s' of the %s module", entry.CustomResourcePolicy, entry.Name),
+				fmt.Sprintf("Use one of: %s, %s", kyma.CustomResourcePolicyCreateAndDelete, kyma.CustomResourcePolicyIgnore))
+		}
+	}
+
+	return nil
+}
+
+// PlanModuleSet compares the module set with the default Kyma CR and module CRs and returns changes required to reach it
+// added modules and changed channels must be available on the cluster
+func PlanModuleSet(ctx context.Context, client kube.Client, moduleSet *ModuleSet) (*ModuleSetPlan, clierror.Error) {
+	kymaCR, err := client.Kyma().GetDefaultKyma(ctx)
+	if err != nil {
+		return nil, clierror.Wrap(err, clierror.New("failed to get the default Kyma CR from the cluster",
+			"Make sure the Kyma CR exists in the kyma-system namespace"))
+	}
+
+	plan := planModuleSet(kymaCR, moduleSet)
+	clierr := validateModuleSetPlan(ctx, client.Kyma(), plan)
+	if clierr != nil {
+		return nil, clierr
+	}
+
+	for _, entry := range moduleSet.Modules {
+		if len(entry.CR) == 0 || isModuleConfigured(ctx, client, entry) {
+			continue
+		}
+
+		plan.Changes = append(plan.Changes, ModuleSetChange{
+			Action: ModuleSetActionConfigure,
+			Module: entry.Name,
+		})
+		plan.configs[entry.Name] = entry.CR
+	}
+
+	return plan, nil
+}
+
+// validateModuleSetPlan checks if added modules and their channels are available on the cluster in the same way as the add command
+func validateModuleSetPlan(ctx context.Context, client kyma.Interface, plan *ModuleSetPlan) clierror.Error {
+	if !slices.ContainsFunc(plan.Changes, func(change ModuleSetChange) bool {
+		return change.Action == ModuleSetActionAdd || change.Action == ModuleSetActionUpdate
+	}) {
+		return nil
+	}
+
+	moduleTemplates, err := client.ListModuleTemplate(ctx)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to list available modules from the cluster"))
+	}
+
+	releaseMetas, err := client.ListModuleReleaseMeta(ctx)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to list module release metas from the cluster"))
+	}
+
+	for _, module := range plan.desiredModules {
+		if !slices.ContainsFunc(plan.Changes, func(change ModuleSetChange) bool {
+			return change.Module == module.Name && (change.Action == ModuleSetActionAdd || change.Action == ModuleSetActionUpdate)
+		}) {
+			continue
+		}
+
+		clierr := checkModuleAndChannel(moduleTemplates, releaseMetas, module.Name, module.Channel)
+		if clierr != nil {
+			return clierr
+		}
+	}
+
+	return nil
+}
+
+// isModuleConfigured returns true if spec of the live module CR already contains all fields of the module set entry CR
+// the module CR is treated as not configured if it can't be read
+func isModuleConfigured(ctx context.Context, client kube.Client, entry ModuleSetEntry) bool {
+	moduleCR, clierr := GetConfig(ctx, client, entry.Name)
+	if clierr != nil {
+		return false
+	}
+
+	return containsFields(moduleCR.Object["spec"], entry.CR["spec"])
+}
+
+// containsFields returns true if all fields of the expected value are set to the same values in the actual value
+func containsFields(actual, expected interface{}) bool {
+	expectedMap, ok := expected.(map[string]interface{})
+	if !ok {
+		return reflect.DeepEqual(normalizeValue(actual), normalizeValue(expected))
+	}
+
+	actualMap, ok := actual.(map[string]interface{})
+	if !ok {
+		return len(expectedMap) == 0 && actual == nil
+	}
+
+	for key, value := range expectedMap {
+		if !containsFields(actualMap[key], value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// normalizeValue converts numbers decoded from yaml and returned by the API server to the same type
+func normalizeValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case int:
+		return float64(typed)
+	case int64:
+		return float64(typed)
+	case []interface{}:
+		normalized := make([]interface{}, len(typed))
+		for i := range typed {
+			normalized[i] = normalizeValue(typed[i])
+		}
+		return normalized
+	case map[string]interface{}:
+		normalized := map[string]interface{}{}
+		for key := range typed {
+			normalized[key] = normalizeValue(typed[key])
+		}
+		return normalized
+	default:
+		return value
+	}
+}
+
+// planModuleSet compares the module set with spec of the Kyma CR
+func planModuleSet(kymaCR *kyma.Kyma, moduleSet *ModuleSet) *ModuleSetPlan {
+	plan := &ModuleSetPlan{
+		moduleSet: moduleSet,
+		configs:   map[string]map[string]interface{}{},
+	}
+
+	if moduleSet.Channel != "" && moduleSet.Channel != kymaCR.Spec.Channel {
+		plan.ChannelChange = describeValueChange("channel", kymaCR.Spec.Channel, moduleSet.Channel)
+		plan.desiredChannel = moduleSet.Channel
+	}
+
+	desiredModules := []kyma.Module{}
+	for _, module := range kymaCR.Spec.Modules {
+		i := slices.IndexFunc(moduleSet.Modules, func(entry ModuleSetEntry) bool {
+			return entry.Name == module.Name
+		})
+		if i == -1 {
+			plan.Changes = append(plan.Changes, ModuleSetChange{
+				Action: ModuleSetActionRemove,
+				Module: module.Name,
+			})
+			continue
+		}
+
+		desired := desiredModule(module, moduleSet.Modules[i])
+		if details := diffModules(module, desired); len(details) > 0 {
+			plan.Changes = append(plan.Changes, ModuleSetChange{
+				Action:  ModuleSetActionUpdate,
+				Module:  module.Name,
+				Details: details,
+			})
+		}
+		desiredModules = append(desiredModules, desired)
+	}
+
+	for _, entry := range moduleSet.Modules {
+		if slices.ContainsFunc(kymaCR.Spec.Modules, func(module kyma.Module) bool {
+			return module.Name == entry.Name
+		}) {
+			continue
+		}
+
+		desired := desiredModule(kyma.Module{Name: entry.Name, Managed: ptr.To(true)}, entry)
+		plan.Changes = append(plan.Changes, ModuleSetChange{
+			Action:  ModuleSetActionAdd,
+			Module:  entry.Name,
+			Details: describeModule(desired),
+		})
+		desiredModules = append(desiredModules, desired)
+	}
+
+	plan.desiredModules = desiredModules
+	return plan
+}
+
+// IsEmpty returns true if there is nothing to change
+func (p *ModuleSetPlan) IsEmpty() bool {
+	return p.ChannelChange == "" && len(p.Changes) == 0
+}
+
+// ApplyModuleSet updates the default Kyma CR and applies module CRs according to the plan
+// if force is false it fails when there are resources in the cluster that block deletion of removed modules
+func ApplyModuleSet(ctx context.Context, client kube.Client, plan *ModuleSetPlan, force bool) clierror.Error {
+	if !force {
+		for _, change := range plan.Changes {
+			if change.Action != ModuleSetActionRemove {
+				continue
+			}
+
+			clierr := checkBlockingResources(ctx, client, change.Module)
+			if clierr != nil {
+				return clierr
+			}
+		}
+	}
+
+	if plan.ChannelChange != "" || slices.ContainsFunc(plan.Changes, func(change ModuleSetChange) bool {
+		return change.Action != ModuleSetActionConfigure
+	}) {
+		err := client.Kyma().PatchDefaultKyma(ctx, func(kymaCR *kyma.Kyma) error {
+			// changes are computed again against the current Kyma CR so changes of other writers are kept on retry
+			current := planModuleSet(kymaCR, plan.moduleSet)
+			if current.desiredChannel != "" {
+				kymaCR.Spec.Channel = current.desiredChannel
+			}
+			kymaCR.Spec.Modules = current.desiredModules
+			return nil
+		})
+		if err != nil {
+			return clierror.Wrap(err, clierror.New("failed to update the default Kyma CR",
+				"Make sure the Kyma CR exists in the kyma-system namespace"))
+		}
+	}
+
+	for _, change := range plan.Changes {
+		if change.Action != ModuleSetActionConfigure {
+			continue
+		}
+
+		clierr := ApplyConfig(ctx, client, change.Module, &unstructured.Unstructured{Object: plan.configs[change.Module]})
+		if clierr != nil {
+			return clierror.WrapE(clierr, clierror.New(fmt.Sprintf("failed to configure the %s module", change.Module),
+				"Wait until the module is installed and run the command again"))
+		}
+	}
+
+	return nil
+}
+
+// RenderModuleSetPlan writes the plan in a human-readable form
+func RenderModuleSetPlan(writer io.Writer, plan *ModuleSetPlan) {
+	if plan.IsEmpty() {
+		fmt.Fprintln(writer, "No changes to apply")
+		return
+	}
+
+	fmt.Fprintln(writer, "Plan:")
+	if plan.ChannelChange != "" {
+		fmt.Fprintf(writer, "  ~ default Kyma CR (%s)\n", plan.ChannelChange)
+	}
+
+	for _, change := range plan.Changes {
+		line := fmt.Sprintf("  %s %s", actionSymbol(change.Action), change.Module)
+		if change.Action == ModuleSetActionConfigure {
+			line += " CR"
+		}
+		if len(change.Details) > 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(change.Details, ", "))
+		}
+
+		fmt.Fprintln(writer, line)
+	}
+}
+
+func actionSymbol(action ModuleSetAction) string {
+	switch action {
+	case ModuleSetActionAdd:
+		return "+"
+	case ModuleSetActionRemove:
+		return "-"
+	case ModuleSetActionConfigure:
+		return "*"
+	default:
+		return "~"
+	}
+}
+
+// desiredModule returns module from the Kyma CR with fields overridden by the module set entry
+func desiredModule(module kyma.Module, entry ModuleSetEntry) kyma.Module {
+	module.Channel = entry.Channel
+	module.CustomResourcePolicy = entry.CustomResourcePolicy
+	if module.CustomResourcePolicy == "" {
+		// the lifecycle-manager default
+		module.CustomResourcePolicy = kyma.CustomResourcePolicyCreateAndDelete
+	}
+
+	// managed is set only if it changes so modules without the field are not patched
+	managed := entry.Managed == nil || *entry.Managed
+	if module.IsManaged() != managed {
+		module.Managed = ptr.To(managed)
+	}
+
+	return module
+}
+
+func diffModules(current, desired kyma.Module) []string {
+	if current.CustomResourcePolicy == "" {
+		current.CustomResourcePolicy = kyma.CustomResourcePolicyCreateAndDelete
+	}
+
+	details := []string{}
+	if current.Channel != desired.Channel {
+		details = append(details, describeValueChange("channel", current.Channel, desired.Channel))
+	}
+	if current.CustomResourcePolicy != desired.CustomResourcePolicy {
+		details = append(details, describeValueChange("customResourcePolicy", current.CustomResourcePolicy, desired.CustomResourcePolicy))
+	}
+	if current.IsManaged() != desired.IsManaged() {
+		details = append(details, describeValueChange("managed", strconv.FormatBool(current.IsManaged()), strconv.FormatBool(desired.IsManaged())))
+	}
+
+	return details
+}
+
+func describeModule(module kyma.Module) []string {
+	details := []string{}
+	if module.Channel != "" {
+		details = append(details, fmt.Sprintf("channel: %s", module.Channel))
+	}
+
+	return append(details,
+		fmt.Sprintf("customResourcePolicy: %s", module.CustomResourcePolicy),
+		fmt.Sprintf("managed: %t", module.IsManaged()),
+	)
+}
+
+func describeValueChange(field, from, to string) string {
+	return fmt.Sprintf("%s: %s -> %s", field, types.ValueOrDash(from), types.ValueOrDash(to))
+}
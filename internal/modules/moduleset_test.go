@@ -0,0 +1,366 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+)
+
+func TestReadModuleSet(t *testing.T) {
+	t.Run("read module set", func(t *testing.T) {
+		path := fixModuleSetFile(t, `channel: regular
+modules:
+  - name: serverless
+    channel: fast
+    customResourcePolicy: Ignore
+    managed: false
+    cr:
+      spec:
+        replicas: 2
+  - name: keda
+`)
+
+		moduleSet, clierr := ReadModuleSet(path)
+		require.Nil(t, clierr)
+		require.Equal(t, &ModuleSet{
+			Channel: "regular",
+			Modules: []ModuleSetEntry{
+				{
+					Name:                 "serverless",
+					Channel:              "fast",
+					CustomResourcePolicy: kyma.CustomResourcePolicyIgnore,
+					Managed:              ptr.To(false),
+					CR: map[string]interface{}{
+						"spec": map[string]interface{}{
+							"replicas": 2,
+						},
+					},
+				},
+				{
+					Name: "keda",
+				},
+			},
+		}, moduleSet)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		path := fixModuleSetFile(t, `modules:
+  - name: serverless
+    version: 1.0.0
+`)
+
+		_, clierr := ReadModuleSet(path)
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "field version not found")
+	})
+
+	t.Run("duplicated module", func(t *testing.T) {
+		path := fixModuleSetFile(t, `modules:
+  - name: serverless
+  - name: serverless
+`)
+
+		_, clierr := ReadModuleSet(path)
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "module serverless is defined more than once")
+	})
+
+	t.Run("invalid custom resource policy", func(t *testing.T) {
+		path := fixModuleSetFile(t, `modules:
+  - name: serverless
+    customResourcePolicy: Delete
+`)
+
+		_, clierr := ReadModuleSet(path)
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "invalid customResourcePolicy 'Delete' of the serverless module")
+	})
+
+	t.Run("unsupported cr field", func(t *testing.T) {
+		path := fixModuleSetFile(t, `modules:
+  - name: serverless
+    cr:
+      status:
+        state: Ready
+`)
+
+		_, clierr := ReadModuleSet(path)
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "unsupported field status in the cr of the serverless module")
+	})
+}
+
+func TestPlanModuleSet(t *testing.T) {
+	t.Run("module not available", func(t *testing.T) {
+		_, client := fixCommunityKubeClient(t, fixCommunityModuleTemplate(t, "0.0.1"), fixModuleSetKymaUnstructured())
+
+		plan, clierr := PlanModuleSet(context.Background(), client, &ModuleSet{
+			Modules: []ModuleSetEntry{
+				{Name: "unknown"},
+			},
+		})
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "module unknown is not available")
+		require.Nil(t, plan)
+	})
+
+	t.Run("skip unchanged module CR", func(t *testing.T) {
+		kymaCR := fixModuleSetKymaUnstructured()
+		kymaCR.Object["spec"].(map[string]interface{})["modules"] = []interface{}{
+			map[string]interface{}{
+				"name":                 "cap",
+				"customResourcePolicy": kyma.CustomResourcePolicyCreateAndDelete,
+				"managed":              true,
+			},
+		}
+		moduleCR := fixTestSecret("cap-default")
+		moduleCR.Object["spec"] = map[string]interface{}{"key": "value", "replicas": int64(2)}
+		_, client := fixCommunityKubeClient(t, fixCommunityModuleTemplate(t, "0.0.1"), kymaCR, moduleCR)
+
+		plan, clierr := PlanModuleSet(context.Background(), client, &ModuleSet{
+			Modules: []ModuleSetEntry{
+				{
+					Name: "cap",
+					CR: map[string]interface{}{
+						"spec": map[string]interface{}{"replicas": 2},
+					},
+				},
+			},
+		})
+		require.Nil(t, clierr)
+		require.True(t, plan.IsEmpty())
+	})
+}
+
+func Test_planModuleSet(t *testing.T) {
+	t.Run("plan changes", func(t *testing.T) {
+		kymaCR := fixModuleSetKyma()
+		moduleSet := &ModuleSet{
+			Channel: "fast",
+			Modules: []ModuleSetEntry{
+				{
+					Name:    "keda",
+					Channel: "fast",
+				},
+				{
+					Name:                 "serverless",
+					CustomResourcePolicy: kyma.CustomResourcePolicyIgnore,
+					Managed:              ptr.To(false),
+					CR: map[string]interface{}{
+						"spec": map[string]interface{}{},
+					},
+				},
+			},
+		}
+
+		plan := planModuleSet(kymaCR, moduleSet)
+		require.Equal(t, "channel: regular -> fast", plan.ChannelChange)
+		require.Equal(t, []ModuleSetChange{
+			{
+				Action: ModuleSetActionRemove,
+				Module: "istio",
+			},
+			{
+				Action:  ModuleSetActionUpdate,
+				Module:  "keda",
+				Details: []string{"channel: - -> fast"},
+			},
+			{
+				Action:  ModuleSetActionAdd,
+				Module:  "serverless",
+				Details: []string{"customResourcePolicy: Ignore", "managed: false"},
+			},
+		}, plan.Changes)
+		require.Equal(t, "fast", plan.desiredChannel)
+		require.Equal(t, []kyma.Module{
+			{
+				Name:                 "keda",
+				Channel:              "fast",
+				CustomResourcePolicy: kyma.CustomResourcePolicyCreateAndDelete,
+				Managed:              ptr.To(true),
+			},
+			{
+				Name:                 "serverless",
+				CustomResourcePolicy: kyma.CustomResourcePolicyIgnore,
+				Managed:              ptr.To(false),
+			},
+		}, plan.desiredModules)
+
+		// given Kyma CR is not modified
+		require.Equal(t, fixModuleSetKyma(), kymaCR)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		plan := planModuleSet(fixModuleSetKyma(), &ModuleSet{
+			Channel: "regular",
+			Modules: []ModuleSetEntry{
+				{
+					Name: "istio",
+				},
+				{
+					Name: "keda",
+				},
+			},
+		})
+		require.True(t, plan.IsEmpty())
+	})
+}
+
+func TestRenderModuleSetPlan(t *testing.T) {
+	t.Run("render plan", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+
+		RenderModuleSetPlan(buffer, &ModuleSetPlan{
+			ChannelChange: "channel: regular -> fast",
+			Changes: []ModuleSetChange{
+				{Action: ModuleSetActionRemove, Module: "istio"},
+				{Action: ModuleSetActionUpdate, Module: "keda", Details: []string{"channel: - -> fast"}},
+				{Action: ModuleSetActionAdd, Module: "serverless", Details: []string{"customResourcePolicy: Ignore", "managed: false"}},
+				{Action: ModuleSetActionConfigure, Module: "serverless"},
+			},
+		})
+		require.Equal(t, `Plan:
+  ~ default Kyma CR (channel: regular -> fast)
+  - istio
+  ~ keda (channel: - -> fast)
+  + serverless (customResourcePolicy: Ignore, managed: false)
+  * serverless CR
+`, buffer.String())
+	})
+
+	t.Run("render empty plan", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+
+		RenderModuleSetPlan(buffer, &ModuleSetPlan{})
+		require.Equal(t, "No changes to apply\n", buffer.String())
+	})
+}
+
+func TestApplyModuleSet(t *testing.T) {
+	t.Run("apply module set", func(t *testing.T) {
+		_, client := fixCommunityKubeClient(t, fixCommunityModuleTemplate(t, "0.0.1"), fixModuleSetKymaUnstructured())
+
+		moduleSet := &ModuleSet{
+			Modules: []ModuleSetEntry{
+				{
+					Name: "cap",
+					CR: map[string]interface{}{
+						"spec": map[string]interface{}{"key": "value"},
+					},
+				},
+			},
+		}
+
+		plan, clierr := PlanModuleSet(context.Background(), client, moduleSet)
+		require.Nil(t, clierr)
+		require.Equal(t, []ModuleSetChange{
+			{
+				Action:  ModuleSetActionAdd,
+				Module:  "cap",
+				Details: []string{"customResourcePolicy: CreateAndDelete", "managed: true"},
+			},
+			{
+				Action: ModuleSetActionConfigure,
+				Module: "cap",
+			},
+		}, plan.Changes)
+
+		clierr = ApplyModuleSet(context.Background(), client, plan, false)
+		require.Nil(t, clierr)
+
+		kymaCR, err := client.Kyma().GetDefaultKyma(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []kyma.Module{
+			{
+				Name:                 "cap",
+				CustomResourcePolicy: kyma.CustomResourcePolicyCreateAndDelete,
+				Managed:              ptr.To(true),
+			},
+		}, kymaCR.Spec.Modules)
+
+		moduleCR, clierr := GetConfig(context.Background(), client, "cap")
+		require.Nil(t, clierr)
+		require.Equal(t, map[string]interface{}{"key": "value"}, moduleCR.Object["spec"])
+	})
+
+	t.Run("keep changes made to the Kyma CR after planning", func(t *testing.T) {
+		_, client := fixCommunityKubeClient(t, fixCommunityModuleTemplate(t, "0.0.1"), fixModuleSetKymaUnstructured())
+
+		plan, clierr := PlanModuleSet(context.Background(), client, &ModuleSet{
+			Modules: []ModuleSetEntry{
+				{Name: "cap"},
+			},
+		})
+		require.Nil(t, clierr)
+
+		// the module is added by another writer between planning and applying
+		err := client.Kyma().PatchDefaultKyma(context.Background(), func(kymaCR *kyma.Kyma) error {
+			kymaCR.Spec.Modules = append(kymaCR.Spec.Modules, kyma.Module{Name: "cap", ControllerName: "custom"})
+			return nil
+		})
+		require.NoError(t, err)
+
+		clierr = ApplyModuleSet(context.Background(), client, plan, false)
+		require.Nil(t, clierr)
+
+		kymaCR, err := client.Kyma().GetDefaultKyma(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []kyma.Module{
+			{
+				Name:                 "cap",
+				ControllerName:       "custom",
+				CustomResourcePolicy: kyma.CustomResourcePolicyCreateAndDelete,
+				// managed isn't set by the other writer and the lifecycle-manager default is kept
+			},
+		}, kymaCR.Spec.Modules)
+	})
+}
+
+func fixModuleSetFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "kyma-modules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func fixModuleSetKyma() *kyma.Kyma {
+	return &kyma.Kyma{
+		Spec: kyma.KymaSpec{
+			Channel: "regular",
+			Modules: []kyma.Module{
+				{
+					Name:                 "istio",
+					CustomResourcePolicy: kyma.CustomResourcePolicyCreateAndDelete,
+					Managed:              ptr.To(true),
+				},
+				{
+					Name:    "keda",
+					Managed: ptr.To(true),
+				},
+			},
+		},
+	}
+}
+
+func fixModuleSetKymaUnstructured() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "operator.kyma-project.io/v1beta2",
+			"kind":       "Kyma",
+			"metadata": map[string]interface{}{
+				"name":      kyma.DefaultKymaName,
+				"namespace": kyma.DefaultKymaNamespace,
+			},
+			"spec": map[string]interface{}{
+				"channel": "regular",
+				"modules": []interface{}{},
+			},
+		},
+	}
+}
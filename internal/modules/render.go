@@ -2,13 +2,14 @@ package modules
 
 import (
 	"cmp"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"slices"
 	"strings"
 
-	"github.com/olekukonko/tablewriter"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
 )
 
 type RowConverter func(Module) []string
@@ -20,24 +21,73 @@ type TableInfo struct {
 
 var (
 	ModulesTableInfo = TableInfo{
-		Header: []string{"NAME", "VERSIONS", "INSTALLED", "MANAGED"},
+		Header: []string{"NAME", "VERSIONS", "INSTALLED", "MANAGED", "STATE"},
 		RowConverter: func(m Module) []string {
 			return []string{
 				m.Name,
 				convertVersions(m.Versions),
 				convertInstall(m.InstallDetails),
 				string(m.InstallDetails.Managed),
+				m.InstallDetails.State,
 			}
 		},
 	}
 )
 
-func Render(modulesList ModulesList, tableInfo TableInfo) {
-	render(os.Stdout, modulesList, tableInfo)
+// structured representation of the module used to render json and yaml outputs
+type moduleOutput struct {
+	Name           string                `json:"name" yaml:"name"`
+	Versions       []moduleVersionOutput `json:"versions" yaml:"versions"`
+	InstallDetails *installDetailsOutput `json:"installDetails,omitempty" yaml:"installDetails,omitempty"`
+}
+
+type moduleVersionOutput struct {
+	Version    string `json:"version" yaml:"version"`
+	Channel    string `json:"channel,omitempty" yaml:"channel,omitempty"`
+	Repository string `json:"repository,omitempty" yaml:"repository,omitempty"`
+}
+
+type installDetailsOutput struct {
+	Version string `json:"version" yaml:"version"`
+	Channel string `json:"channel,omitempty" yaml:"channel,omitempty"`
+	Managed bool   `json:"managed" yaml:"managed"`
+	State   string `json:"state,omitempty" yaml:"state,omitempty"`
+}
+
+// Render renders modules list to the stdout in the given format
+func Render(modulesList ModulesList, tableInfo TableInfo, format types.Format) error {
+	return renderFormat(os.Stdout, modulesList, tableInfo, format)
+}
+
+func renderFormat(writer io.Writer, modulesList ModulesList, tableInfo TableInfo, format types.Format) error {
+	return types.RenderFormat(writer, convertModuleListToOutput(modulesList), format, func(writer io.Writer) {
+		render(writer, modulesList, tableInfo)
+	})
+}
+
+// RenderWatch renders modules list to the stdout in the watch mode
+// the table is redrawn, json output is written as a single line and yaml output as a separate document
+func RenderWatch(modulesList ModulesList, tableInfo TableInfo, format types.Format) error {
+	return renderWatch(os.Stdout, modulesList, tableInfo, format)
+}
+
+func renderWatch(writer io.Writer, modulesList ModulesList, tableInfo TableInfo, format types.Format) error {
+	switch format {
+	case types.JSONFormat:
+		return json.NewEncoder(writer).Encode(convertModuleListToOutput(modulesList))
+	case types.YAMLFormat:
+		fmt.Fprintln(writer, "---")
+		return types.RenderYAML(writer, convertModuleListToOutput(modulesList))
+	default:
+		// move cursor to the top left corner and clear the screen
+		fmt.Fprint(writer, "\033[H\033[2J")
+		render(writer, modulesList, tableInfo)
+		return nil
+	}
 }
 
 func render(writer io.Writer, modulesList ModulesList, tableInfo TableInfo) {
-	renderTable(
+	types.RenderTable(
 		writer,
 		convertModuleListToTable(modulesList, tableInfo.RowConverter),
 		tableInfo.Header,
@@ -56,27 +106,43 @@ func convertModuleListToTable(modulesList ModulesList, rowConverter RowConverter
 	return result
 }
 
-// renderTable renders the table with the provided headers and data
-func renderTable(writer io.Writer, modulesData [][]string, headers []string) {
-	twTable := setTable(writer)
-	twTable.AppendBulk(modulesData)
-	twTable.SetHeader(headers)
-	twTable.Render()
+func convertModuleListToOutput(modulesList ModulesList) []moduleOutput {
+	slices.SortFunc(modulesList, func(a, b Module) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	result := make([]moduleOutput, len(modulesList))
+	for i, module := range modulesList {
+		result[i] = moduleOutput{
+			Name:           module.Name,
+			Versions:       make([]moduleVersionOutput, len(module.Versions)),
+			InstallDetails: convertInstallDetailsToOutput(module.InstallDetails),
+		}
+
+		for j, version := range module.Versions {
+			result[i].Versions[j] = moduleVersionOutput{
+				Version:    version.Version,
+				Channel:    version.Channel,
+				Repository: version.Repository,
+			}
+		}
+	}
+
+	return result
 }
 
-// setTable sets the table settings for the tablewriter
-func setTable(writer io.Writer) *tablewriter.Table {
-	table := tablewriter.NewWriter(writer)
-	table.SetRowLine(false)
-	table.SetHeaderLine(false)
-	table.SetColumnSeparator("")
-	table.SetAlignment(tablewriter.ALIGN_LEFT)
-	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
-	table.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT, tablewriter.ALIGN_LEFT})
-	table.SetBorder(false)
-	table.SetTablePadding("\t")
-	table.SetNoWhiteSpace(true)
-	return table
+// return nil if module is not installed
+func convertInstallDetailsToOutput(details ModuleInstallDetails) *installDetailsOutput {
+	if details == (ModuleInstallDetails{}) {
+		return nil
+	}
+
+	return &installDetailsOutput{
+		Version: details.Version,
+		Channel: details.Channel,
+		Managed: details.Managed == ManagedTrue,
+		State:   details.State,
+	}
 }
 
 // convert version and channel into field in format 'version (channel)' for core modules and 'version' for community ones
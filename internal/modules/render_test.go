@@ -3,14 +3,74 @@ package modules
 import (
 	"bytes"
 	"io"
+	"strings"
 	"testing"
 
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
 	"github.com/stretchr/testify/require"
 )
 
 const (
-	testModulesTableView        = "NAME      \tVERSIONS               \tINSTALLED\tMANAGED \nkeda      \t0.1(regular), 0.2(fast)\t         \t       \t\nserverless\t0.0.1(fast), 0.0.2     \t         \t       \t\n"
-	testManagedModulesTableView = "NAME      \tVERSIONS               \tINSTALLED  \tMANAGED \nkeda      \t0.1(regular), 0.2(fast)\t0.2(fast)  \ttrue   \t\nserverless\t0.0.1(fast), 0.0.2     \t0.0.1(fast)\tfalse  \t\n"
+	testModulesTableView       = "NAME      \tVERSIONS               \tINSTALLED\tMANAGED\tSTATE \nkeda      \t0.1(regular), 0.2(fast)\t         \t       \t     \t\nserverless\t0.0.1(fast), 0.0.2     \t         \t       \t     \t\n"
+	testManagedModulesJSONView = `[
+  {
+    "name": "keda",
+    "versions": [
+      {
+        "version": "0.1",
+        "channel": "regular",
+        "repository": "url-3"
+      },
+      {
+        "version": "0.2",
+        "channel": "fast"
+      }
+    ],
+    "installDetails": {
+      "version": "0.2",
+      "channel": "fast",
+      "managed": true,
+      "state": "Ready"
+    }
+  },
+  {
+    "name": "serverless",
+    "versions": [
+      {
+        "version": "0.0.1",
+        "channel": "fast",
+        "repository": "url-1"
+      },
+      {
+        "version": "0.0.2",
+        "repository": "url-2"
+      }
+    ],
+    "installDetails": {
+      "version": "0.0.1",
+      "channel": "fast",
+      "managed": false,
+      "state": "Unmanaged"
+    }
+  }
+]
+`
+	testModulesYAMLView = `- name: keda
+  versions:
+    - version: "0.1"
+      channel: regular
+      repository: url-3
+    - version: "0.2"
+      channel: fast
+- name: serverless
+  versions:
+    - version: 0.0.1
+      channel: fast
+      repository: url-1
+    - version: 0.0.2
+      repository: url-2
+`
+	testManagedModulesTableView = "NAME      \tVERSIONS               \tINSTALLED  \tMANAGED\tSTATE     \nkeda      \t0.1(regular), 0.2(fast)\t0.2(fast)  \ttrue   \tReady    \t\nserverless\t0.0.1(fast), 0.0.2     \t0.0.1(fast)\tfalse  \tUnmanaged\t\n"
 )
 
 func TestRender(t *testing.T) {
@@ -34,3 +94,56 @@ func TestRender(t *testing.T) {
 		require.Equal(t, testManagedModulesTableView, string(tableViewBytes))
 	})
 }
+
+func TestRenderFormat(t *testing.T) {
+	t.Run("render table by default", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+
+		err := renderFormat(buffer, testModuleList, ModulesTableInfo, types.DefaultFormat)
+		require.NoError(t, err)
+		require.Equal(t, testModulesTableView, buffer.String())
+	})
+
+	t.Run("render json from managed modules", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+
+		err := renderFormat(buffer, testManagedModuleList, ModulesTableInfo, types.JSONFormat)
+		require.NoError(t, err)
+		require.Equal(t, testManagedModulesJSONView, buffer.String())
+	})
+
+	t.Run("render yaml from modules", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+
+		err := renderFormat(buffer, testModuleList, ModulesTableInfo, types.YAMLFormat)
+		require.NoError(t, err)
+		require.Equal(t, testModulesYAMLView, buffer.String())
+	})
+}
+
+func TestRenderWatch(t *testing.T) {
+	t.Run("redraw table", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+
+		err := renderWatch(buffer, testModuleList, ModulesTableInfo, types.DefaultFormat)
+		require.NoError(t, err)
+		require.Equal(t, "\033[H\033[2J"+testModulesTableView, buffer.String())
+	})
+
+	t.Run("render json line", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+
+		err := renderWatch(buffer, testManagedModuleList, ModulesTableInfo, types.JSONFormat)
+		require.NoError(t, err)
+		require.Equal(t, 1, strings.Count(buffer.String(), "\n"))
+		require.JSONEq(t, testManagedModulesJSONView, buffer.String())
+	})
+
+	t.Run("render yaml document", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+
+		err := renderWatch(buffer, testModuleList, ModulesTableInfo, types.YAMLFormat)
+		require.NoError(t, err)
+		require.Equal(t, "---\n"+testModulesYAMLView, buffer.String())
+	})
+}
@@ -0,0 +1,75 @@
+package modules
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"github.com/kyma-project/cli.v3/internal/kube/rootlessdynamic"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// state used when the module CR can't be read from the cluster
+	ModuleStateUnknown = "Unknown"
+)
+
+// getModuleCRState reads the module CR pointed by the ModuleTemplate manager
+// and computes its state using customStateCheck rules or the default .status.state field
+func getModuleCRState(ctx context.Context, client rootlessdynamic.Interface, moduleTemplate *kyma.ModuleTemplate) string {
+	manager := moduleTemplate.Spec.Manager
+	moduleCR, err := client.Get(ctx, &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": metav1.GroupVersion{Group: manager.Group, Version: manager.Version}.String(),
+			"kind":       manager.Kind,
+			"metadata": map[string]interface{}{
+				"name":      manager.Name,
+				"namespace": manager.Namespace,
+			},
+		},
+	})
+	if err != nil {
+		return ModuleStateUnknown
+	}
+
+	return computeState(moduleCR, moduleTemplate.Spec.CustomStateCheck)
+}
+
+// computeState returns state of the resource based on the customStateCheck rules in the same way as the lifecycle-manager
+// the Error state has the highest priority, then Warning and Ready; Processing is returned when no rule matches
+func computeState(obj *unstructured.Unstructured, checks []kyma.CustomStateCheck) string {
+	if len(checks) == 0 {
+		state, _, _ := unstructured.NestedString(obj.Object, "status", "state")
+		if state == "" {
+			return ModuleStateUnknown
+		}
+		return state
+	}
+
+	mappedStates := []string{}
+	for _, check := range checks {
+		value, found, err := unstructured.NestedFieldNoCopy(obj.Object, jsonPathToFields(check.JSONPath)...)
+		if err != nil || !found {
+			continue
+		}
+
+		if stringValue, ok := value.(string); ok && stringValue == check.Value {
+			mappedStates = append(mappedStates, check.MappedState)
+		}
+	}
+
+	for _, state := range []string{kyma.ModuleStateError, kyma.ModuleStateWarning, kyma.ModuleStateReady} {
+		if slices.Contains(mappedStates, state) {
+			return state
+		}
+	}
+
+	return kyma.ModuleStateProcessing
+}
+
+// convert path in format 'status.state' or '.status.state' to the list of fields
+func jsonPathToFields(jsonPath string) []string {
+	return strings.Split(strings.TrimPrefix(jsonPath, "."), ".")
+}
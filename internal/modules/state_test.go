@@ -0,0 +1,170 @@
+package modules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"github.com/kyma-project/cli.v3/internal/kube/rootlessdynamic"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	discovery_fake "k8s.io/client-go/discovery/fake"
+	dynamic_fake "k8s.io/client-go/dynamic/fake"
+	clientgo_testing "k8s.io/client-go/testing"
+)
+
+func Test_computeState(t *testing.T) {
+	t.Parallel()
+	checks := []kyma.CustomStateCheck{
+		{
+			JSONPath:    "status.health",
+			Value:       "green",
+			MappedState: kyma.ModuleStateReady,
+		},
+		{
+			JSONPath:    "status.health",
+			Value:       "yellow",
+			MappedState: kyma.ModuleStateWarning,
+		},
+		{
+			JSONPath:    ".status.phase",
+			Value:       "Failed",
+			MappedState: kyma.ModuleStateError,
+		},
+	}
+
+	tests := []struct {
+		name   string
+		status map[string]interface{}
+		checks []kyma.CustomStateCheck
+		want   string
+	}{
+		{
+			name: "default state field",
+			status: map[string]interface{}{
+				"state": "Ready",
+			},
+			want: kyma.ModuleStateReady,
+		},
+		{
+			name:   "missing default state field",
+			status: map[string]interface{}{},
+			want:   ModuleStateUnknown,
+		},
+		{
+			name: "ready mapped state",
+			status: map[string]interface{}{
+				"health": "green",
+			},
+			checks: checks,
+			want:   kyma.ModuleStateReady,
+		},
+		{
+			name: "warning mapped state",
+			status: map[string]interface{}{
+				"health": "yellow",
+			},
+			checks: checks,
+			want:   kyma.ModuleStateWarning,
+		},
+		{
+			name: "error mapped state has the highest priority",
+			status: map[string]interface{}{
+				"health": "green",
+				"phase":  "Failed",
+			},
+			checks: checks,
+			want:   kyma.ModuleStateError,
+		},
+		{
+			name: "no rule matches",
+			status: map[string]interface{}{
+				"health": "red",
+			},
+			checks: checks,
+			want:   kyma.ModuleStateProcessing,
+		},
+	}
+	for _, tt := range tests {
+		status := tt.status
+		checks := tt.checks
+		want := tt.want
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"status": status,
+				},
+			}
+
+			require.Equal(t, want, computeState(obj, checks))
+		})
+	}
+}
+
+func Test_getModuleCRState(t *testing.T) {
+	moduleTemplate := &kyma.ModuleTemplate{
+		Spec: kyma.ModuleTemplateSpec{
+			Manager: kyma.Manager{
+				GroupVersionKind: metav1.GroupVersionKind{
+					Group:   "operator.kyma-project.io",
+					Version: "v1alpha1",
+					Kind:    "Serverless",
+				},
+				Name:      "default",
+				Namespace: "kyma-system",
+			},
+		},
+	}
+
+	t.Run("get state from module CR", func(t *testing.T) {
+		client := fixStateRootlessClient(&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "operator.kyma-project.io/v1alpha1",
+				"kind":       "Serverless",
+				"metadata": map[string]interface{}{
+					"name":      "default",
+					"namespace": "kyma-system",
+				},
+				"status": map[string]interface{}{
+					"state": "Warning",
+				},
+			},
+		})
+
+		state := getModuleCRState(context.Background(), client, moduleTemplate)
+		require.Equal(t, kyma.ModuleStateWarning, state)
+	})
+
+	t.Run("module CR not found", func(t *testing.T) {
+		client := fixStateRootlessClient()
+
+		state := getModuleCRState(context.Background(), client, moduleTemplate)
+		require.Equal(t, ModuleStateUnknown, state)
+	})
+}
+
+func fixStateRootlessClient(objs ...runtime.Object) rootlessdynamic.Interface {
+	return rootlessdynamic.NewClient(
+		dynamic_fake.NewSimpleDynamicClient(runtime.NewScheme(), objs...),
+		&discovery_fake.FakeDiscovery{
+			Fake: &clientgo_testing.Fake{
+				Resources: []*metav1.APIResourceList{
+					{
+						GroupVersion: "operator.kyma-project.io/v1alpha1",
+						APIResources: []metav1.APIResource{
+							{
+								Group:      "operator.kyma-project.io",
+								Version:    "v1alpha1",
+								Kind:       "Serverless",
+								Name:       "serverlesses",
+								Namespaced: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,237 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kyma-project/cli.v3/internal/clierror"
+	"github.com/kyma-project/cli.v3/internal/cmdcommon/types"
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+)
+
+// ChannelUpgrade describes move of the module from its installed version to the version assigned to the target channel
+type ChannelUpgrade struct {
+	Module      string
+	FromVersion string
+	FromChannel string
+	ToVersion   string
+	ToChannel   string
+	// Skipped is true if the target channel is not available for the module so it stays in its current channel
+	Skipped bool
+}
+
+// IsDowngrade returns true if the target version is lower than the installed one
+func (u ChannelUpgrade) IsDowngrade() bool {
+	return !u.Skipped && u.FromVersion != "" && compareVersions(u.ToVersion, u.FromVersion) < 0
+}
+
+// PlanModuleUpgrade returns the upgrade of the module from the default Kyma CR to the given channel
+func PlanModuleUpgrade(ctx context.Context, client kyma.Interface, module, channel string) (*ChannelUpgrade, clierror.Error) {
+	kymaCR, releaseMetas, clierr := getUpgradeResources(ctx, client)
+	if clierr != nil {
+		return nil, clierr
+	}
+
+	kymaModule := findKymaModule(kymaCR, module)
+	if kymaModule == nil {
+		return nil, clierror.New(fmt.Sprintf("module %s is not added to the default Kyma CR", module),
+			"Use the 'kyma alpha modules add' command to add the module")
+	}
+
+	return planUpgrade(kymaCR, releaseMetas, *kymaModule, channel)
+}
+
+// PlanChannelUpgrade returns upgrades of all modules from the default Kyma CR following its default channel
+// modules with their own channel are not affected by the change of the default channel
+// modules without the target channel are returned as skipped
+func PlanChannelUpgrade(ctx context.Context, client kyma.Interface, channel string) ([]ChannelUpgrade, clierror.Error) {
+	kymaCR, releaseMetas, clierr := getUpgradeResources(ctx, client)
+	if clierr != nil {
+		return nil, clierr
+	}
+
+	upgrades := []ChannelUpgrade{}
+	for _, module := range kymaCR.Spec.Modules {
+		if module.Channel != "" {
+			continue
+		}
+
+		if isChannelUnavailable(releaseMetas, module, channel) {
+			upgrades = append(upgrades, ChannelUpgrade{
+				Module:      module.Name,
+				FromVersion: getInstalledVersion(kymaCR, module.Name),
+				FromChannel: kymaCR.Spec.Channel,
+				ToChannel:   channel,
+				Skipped:     true,
+			})
+			continue
+		}
+
+		upgrade, clierr := planUpgrade(kymaCR, releaseMetas, module, channel)
+		if clierr != nil {
+			return nil, clierr
+		}
+
+		upgrades = append(upgrades, *upgrade)
+	}
+
+	return upgrades, nil
+}
+
+// UpgradeModule sets the channel of the module in the default Kyma CR
+func UpgradeModule(ctx context.Context, client kyma.Interface, module, channel string) clierror.Error {
+	err := client.PatchDefaultKyma(ctx, func(kymaCR *kyma.Kyma) error {
+		kymaModule := findKymaModule(kymaCR, module)
+		if kymaModule == nil {
+			return fmt.Errorf("module %s not found", module)
+		}
+
+		kymaModule.Channel = channel
+		return nil
+	})
+	if err != nil {
+		return clierror.Wrap(err, clierror.New(fmt.Sprintf("failed to change channel of the %s module in the default Kyma CR", module)))
+	}
+
+	return nil
+}
+
+// UpgradeChannel sets the default channel of the default Kyma CR
+// modules without the target channel are pinned to the current default channel so they are not moved to the unavailable channel
+// skipped modules are found in the patched Kyma CR so changes made after planning are not overridden
+func UpgradeChannel(ctx context.Context, client kyma.Interface, channel string) clierror.Error {
+	releaseMetas, err := client.ListModuleReleaseMeta(ctx)
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to list module release metas from the cluster"))
+	}
+
+	err = client.PatchDefaultKyma(ctx, func(kymaCR *kyma.Kyma) error {
+		for i := range kymaCR.Spec.Modules {
+			kymaModule := &kymaCR.Spec.Modules[i]
+			if kymaModule.Channel == "" && isChannelUnavailable(releaseMetas, *kymaModule, channel) {
+				kymaModule.Channel = kymaCR.Spec.Channel
+			}
+		}
+
+		kymaCR.Spec.Channel = channel
+		return nil
+	})
+	if err != nil {
+		return clierror.Wrap(err, clierror.New("failed to change channel of the default Kyma CR"))
+	}
+
+	return nil
+}
+
+// CheckDowngrades returns error if any of upgrades is a downgrade and downgrades are not allowed
+func CheckDowngrades(upgrades []ChannelUpgrade, allowDowngrade bool) clierror.Error {
+	if allowDowngrade {
+		return nil
+	}
+
+	downgraded := []string{}
+	for _, upgrade := range upgrades {
+		if upgrade.IsDowngrade() {
+			downgraded = append(downgraded, upgrade.Module)
+		}
+	}
+
+	if len(downgraded) > 0 {
+		return clierror.New(fmt.Sprintf("switching channel downgrades modules: %s", strings.Join(downgraded, ", ")),
+			"Use the --allow-downgrade flag to downgrade anyway")
+	}
+
+	return nil
+}
+
+// RenderUpgrades writes upgrades in a human-readable form
+func RenderUpgrades(writer io.Writer, upgrades []ChannelUpgrade) {
+	if len(upgrades) == 0 {
+		fmt.Fprintln(writer, "No modules affected by the channel change")
+		return
+	}
+
+	for _, upgrade := range upgrades {
+		if upgrade.Skipped {
+			fmt.Fprintf(writer, "%s: %s(%s) skipped, channel %s is not available\n", upgrade.Module,
+				types.ValueOrDash(upgrade.FromVersion), types.ValueOrDash(upgrade.FromChannel), upgrade.ToChannel)
+			continue
+		}
+
+		fmt.Fprintf(writer, "%s: %s(%s) -> %s(%s)\n", upgrade.Module,
+			types.ValueOrDash(upgrade.FromVersion), types.ValueOrDash(upgrade.FromChannel), upgrade.ToVersion, upgrade.ToChannel)
+		if upgrade.IsDowngrade() {
+			fmt.Fprintf(writer, "  warning: %s will be downgraded from %s to %s\n", upgrade.Module, upgrade.FromVersion, upgrade.ToVersion)
+		}
+	}
+}
+
+// isChannelUnavailable returns true if no version of the module is assigned to the channel
+func isChannelUnavailable(releaseMetas *kyma.ModuleReleaseMetaList, module kyma.Module, channel string) bool {
+	return getAssignedVersion(*releaseMetas, module.Name, channel) == ""
+}
+
+func getUpgradeResources(ctx context.Context, client kyma.Interface) (*kyma.Kyma, *kyma.ModuleReleaseMetaList, clierror.Error) {
+	kymaCR, err := client.GetDefaultKyma(ctx)
+	if err != nil {
+		return nil, nil, clierror.Wrap(err, clierror.New("failed to get the default Kyma CR from the cluster",
+			"Make sure the Kyma CR exists in the kyma-system namespace"))
+	}
+
+	releaseMetas, err := client.ListModuleReleaseMeta(ctx)
+	if err != nil {
+		return nil, nil, clierror.Wrap(err, clierror.New("failed to list module release metas from the cluster"))
+	}
+
+	return kymaCR, releaseMetas, nil
+}
+
+func planUpgrade(kymaCR *kyma.Kyma, releaseMetas *kyma.ModuleReleaseMetaList, module kyma.Module, channel string) (*ChannelUpgrade, clierror.Error) {
+	toVersion := getAssignedVersion(*releaseMetas, module.Name, channel)
+	if toVersion == "" {
+		return nil, clierror.New(fmt.Sprintf("channel %s is not available for the %s module", channel, module.Name),
+			fmt.Sprintf("Use one of the available channels: %s", strings.Join(getAvailableChannels(*releaseMetas, module.Name), ", ")))
+	}
+
+	fromChannel := module.Channel
+	if fromChannel == "" {
+		fromChannel = kymaCR.Spec.Channel
+	}
+
+	return &ChannelUpgrade{
+		Module:      module.Name,
+		FromVersion: getInstalledVersion(kymaCR, module.Name),
+		FromChannel: fromChannel,
+		ToVersion:   toVersion,
+		ToChannel:   channel,
+	}, nil
+}
+
+// look for version assigned to the channel of the module with specified name
+func getAssignedVersion(releaseMetas kyma.ModuleReleaseMetaList, moduleName, channel string) string {
+	for _, releaseMeta := range releaseMetas.Items {
+		if releaseMeta.Spec.ModuleName != moduleName {
+			continue
+		}
+
+		for _, assignment := range releaseMeta.Spec.Channels {
+			if assignment.Channel == channel {
+				return assignment.Version
+			}
+		}
+	}
+
+	return ""
+}
+
+func findKymaModule(kymaCR *kyma.Kyma, moduleName string) *kyma.Module {
+	for i := range kymaCR.Spec.Modules {
+		if kymaCR.Spec.Modules[i].Name == moduleName {
+			return &kymaCR.Spec.Modules[i]
+		}
+	}
+
+	return nil
+}
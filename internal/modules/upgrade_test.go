@@ -0,0 +1,263 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamic_fake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestPlanModuleUpgrade(t *testing.T) {
+	t.Run("plan module upgrade", func(t *testing.T) {
+		client := fixUpgradeKymaClient()
+
+		upgrade, clierr := PlanModuleUpgrade(context.Background(), client, "keda", "fast")
+		require.Nil(t, clierr)
+		require.Equal(t, &ChannelUpgrade{
+			Module:      "keda",
+			FromVersion: "0.1",
+			FromChannel: "regular",
+			ToVersion:   "0.2",
+			ToChannel:   "fast",
+		}, upgrade)
+		require.False(t, upgrade.IsDowngrade())
+	})
+
+	t.Run("plan module downgrade", func(t *testing.T) {
+		client := fixUpgradeKymaClient()
+
+		upgrade, clierr := PlanModuleUpgrade(context.Background(), client, "serverless", "regular")
+		require.Nil(t, clierr)
+		require.Equal(t, &ChannelUpgrade{
+			Module:      "serverless",
+			FromVersion: "0.0.2",
+			FromChannel: "fast",
+			ToVersion:   "0.0.1",
+			ToChannel:   "regular",
+		}, upgrade)
+		require.True(t, upgrade.IsDowngrade())
+	})
+
+	t.Run("channel not available", func(t *testing.T) {
+		client := fixUpgradeKymaClient()
+
+		_, clierr := PlanModuleUpgrade(context.Background(), client, "keda", "experimental")
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "channel experimental is not available for the keda module")
+		require.Contains(t, clierr.String(), "Use one of the available channels: regular, fast")
+	})
+
+	t.Run("module not added", func(t *testing.T) {
+		client := fixUpgradeKymaClient()
+
+		_, clierr := PlanModuleUpgrade(context.Background(), client, "istio", "fast")
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "module istio is not added to the default Kyma CR")
+	})
+}
+
+func TestPlanChannelUpgrade(t *testing.T) {
+	t.Run("plan upgrade of modules without own channel", func(t *testing.T) {
+		client := fixUpgradeKymaClient()
+
+		upgrades, clierr := PlanChannelUpgrade(context.Background(), client, "fast")
+		require.Nil(t, clierr)
+		require.Equal(t, []ChannelUpgrade{
+			{
+				Module:      "keda",
+				FromVersion: "0.1",
+				FromChannel: "regular",
+				ToVersion:   "0.2",
+				ToChannel:   "fast",
+			},
+		}, upgrades)
+	})
+
+	t.Run("skip modules without target channel", func(t *testing.T) {
+		client := fixUpgradeKymaClient()
+
+		upgrades, clierr := PlanChannelUpgrade(context.Background(), client, "experimental")
+		require.Nil(t, clierr)
+		require.Equal(t, []ChannelUpgrade{
+			{
+				Module:      "keda",
+				FromVersion: "0.1",
+				FromChannel: "regular",
+				ToChannel:   "experimental",
+				Skipped:     true,
+			},
+		}, upgrades)
+	})
+}
+
+func TestUpgrade(t *testing.T) {
+	t.Run("upgrade module", func(t *testing.T) {
+		client := fixUpgradeKymaClient()
+
+		clierr := UpgradeModule(context.Background(), client, "keda", "fast")
+		require.Nil(t, clierr)
+
+		kymaCR, err := client.GetDefaultKyma(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "fast", findKymaModule(kymaCR, "keda").Channel)
+		require.Equal(t, "regular", kymaCR.Spec.Channel)
+	})
+
+	t.Run("upgrade default channel", func(t *testing.T) {
+		client := fixUpgradeKymaClient()
+
+		clierr := UpgradeChannel(context.Background(), client, "fast")
+		require.Nil(t, clierr)
+
+		kymaCR, err := client.GetDefaultKyma(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "fast", kymaCR.Spec.Channel)
+		require.Equal(t, "", findKymaModule(kymaCR, "keda").Channel)
+	})
+
+	t.Run("keep skipped module in current channel", func(t *testing.T) {
+		client := fixUpgradeKymaClient()
+
+		clierr := UpgradeChannel(context.Background(), client, "experimental")
+		require.Nil(t, clierr)
+
+		kymaCR, err := client.GetDefaultKyma(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "experimental", kymaCR.Spec.Channel)
+		require.Equal(t, "regular", findKymaModule(kymaCR, "keda").Channel)
+	})
+
+	t.Run("keep changes made to the Kyma CR after planning", func(t *testing.T) {
+		client := fixUpgradeKymaClient()
+
+		upgrades, clierr := PlanChannelUpgrade(context.Background(), client, "experimental")
+		require.Nil(t, clierr)
+		require.Len(t, upgrades, 1)
+
+		// keda gets its own channel and serverless starts following the default channel
+		err := client.PatchDefaultKyma(context.Background(), func(kymaCR *kyma.Kyma) error {
+			findKymaModule(kymaCR, "keda").Channel = "fast"
+			findKymaModule(kymaCR, "serverless").Channel = ""
+			return nil
+		})
+		require.NoError(t, err)
+
+		clierr = UpgradeChannel(context.Background(), client, "experimental")
+		require.Nil(t, clierr)
+
+		kymaCR, err := client.GetDefaultKyma(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "experimental", kymaCR.Spec.Channel)
+		require.Equal(t, "fast", findKymaModule(kymaCR, "keda").Channel)
+		require.Equal(t, "regular", findKymaModule(kymaCR, "serverless").Channel)
+	})
+}
+
+func TestCheckDowngrades(t *testing.T) {
+	upgrades := []ChannelUpgrade{
+		{Module: "keda", FromVersion: "0.1", ToVersion: "0.2"},
+		{Module: "serverless", FromVersion: "0.0.2", ToVersion: "0.0.1"},
+		{Module: "istio", ToVersion: "0.0.1"},
+	}
+
+	t.Run("refuse downgrade", func(t *testing.T) {
+		clierr := CheckDowngrades(upgrades, false)
+		require.NotNil(t, clierr)
+		require.Contains(t, clierr.String(), "switching channel downgrades modules: serverless")
+		require.Contains(t, clierr.String(), "Use the --allow-downgrade flag to downgrade anyway")
+	})
+
+	t.Run("allow downgrade", func(t *testing.T) {
+		require.Nil(t, CheckDowngrades(upgrades, true))
+	})
+}
+
+func TestRenderUpgrades(t *testing.T) {
+	t.Run("render upgrades", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+
+		RenderUpgrades(buffer, []ChannelUpgrade{
+			{Module: "keda", FromVersion: "0.1", FromChannel: "regular", ToVersion: "0.2", ToChannel: "fast"},
+			{Module: "serverless", FromVersion: "0.0.2", FromChannel: "fast", ToVersion: "0.0.1", ToChannel: "regular"},
+			{Module: "istio", FromChannel: "regular", ToVersion: "1.0.0", ToChannel: "fast"},
+			{Module: "eventing", ToVersion: "1.0.0", ToChannel: "fast"},
+			{Module: "api-gateway", FromVersion: "2.0.0", FromChannel: "regular", ToChannel: "fast", Skipped: true},
+		})
+		require.Equal(t, `keda: 0.1(regular) -> 0.2(fast)
+serverless: 0.0.2(fast) -> 0.0.1(regular)
+  warning: serverless will be downgraded from 0.0.2 to 0.0.1
+istio: -(regular) -> 1.0.0(fast)
+eventing: -(-) -> 1.0.0(fast)
+api-gateway: 2.0.0(regular) skipped, channel fast is not available
+`, buffer.String())
+	})
+
+	t.Run("render no upgrades", func(t *testing.T) {
+		buffer := bytes.NewBuffer([]byte{})
+
+		RenderUpgrades(buffer, []ChannelUpgrade{})
+		require.Equal(t, "No modules affected by the channel change\n", buffer.String())
+	})
+}
+
+func fixUpgradeKymaClient() kyma.Interface {
+	serverlessReleaseMeta := testReleaseMeta1.DeepCopy()
+	_ = unstructured.SetNestedSlice(serverlessReleaseMeta.Object, []interface{}{
+		map[string]interface{}{
+			"version": "0.0.1",
+			"channel": "regular",
+		},
+		map[string]interface{}{
+			"version": "0.0.2",
+			"channel": "fast",
+		},
+	}, "spec", "channels")
+
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypes(kyma.GVRModuleReleaseMeta.GroupVersion())
+	return kyma.NewClient(dynamic_fake.NewSimpleDynamicClient(scheme,
+		serverlessReleaseMeta,
+		&testReleaseMeta2,
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "operator.kyma-project.io/v1beta2",
+				"kind":       "Kyma",
+				"metadata": map[string]interface{}{
+					"name":      kyma.DefaultKymaName,
+					"namespace": kyma.DefaultKymaNamespace,
+				},
+				"spec": map[string]interface{}{
+					"channel": "regular",
+					"modules": []interface{}{
+						map[string]interface{}{
+							"name":    "serverless",
+							"channel": "fast",
+							"managed": true,
+						},
+						map[string]interface{}{
+							"name":    "keda",
+							"managed": true,
+						},
+					},
+				},
+				"status": map[string]interface{}{
+					"modules": []interface{}{
+						map[string]interface{}{
+							"name":    "serverless",
+							"version": "0.0.2",
+						},
+						map[string]interface{}{
+							"name":    "keda",
+							"version": "0.1",
+						},
+					},
+				},
+			},
+		},
+	))
+}
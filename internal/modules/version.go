@@ -0,0 +1,46 @@
+package modules
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// compareVersions compares two module versions
+// returns -1 if a is lower than b, 0 if they are equal and 1 if a is greater than b
+// versions that are not valid semantic versions are compared as strings
+func compareVersions(a, b string) int {
+	if va, vb, ok := parseVersions(version.ParseSemantic, a, b); ok {
+		return compareParsedVersions(va, vb)
+	}
+
+	if va, vb, ok := parseVersions(version.ParseGeneric, a, b); ok {
+		return compareParsedVersions(va, vb)
+	}
+
+	return strings.Compare(a, b)
+}
+
+func compareParsedVersions(a, b *version.Version) int {
+	if a.LessThan(b) {
+		return -1
+	}
+	if b.LessThan(a) {
+		return 1
+	}
+	return 0
+}
+
+func parseVersions(parse func(string) (*version.Version, error), a, b string) (*version.Version, *version.Version, bool) {
+	va, err := parse(a)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	vb, err := parse(b)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	return va, vb, true
+}
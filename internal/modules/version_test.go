@@ -0,0 +1,52 @@
+package modules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_compareVersions(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{
+			name: "semantic versions",
+			a:    "1.2.10",
+			b:    "1.2.9",
+			want: 1,
+		},
+		{
+			name: "pre-release is lower than release",
+			a:    "1.0.0-rc.1",
+			b:    "1.0.0",
+			want: -1,
+		},
+		{
+			name: "generic versions",
+			a:    "0.2",
+			b:    "0.10",
+			want: -1,
+		},
+		{
+			name: "equal versions",
+			a:    "0.1.0",
+			b:    "0.1.0",
+			want: 0,
+		},
+		{
+			name: "invalid versions are compared as strings",
+			a:    "latest",
+			b:    "0.1.0",
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, compareVersions(tt.a, tt.b))
+		})
+	}
+}
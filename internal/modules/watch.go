@@ -0,0 +1,171 @@
+package modules
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/kyma-project/cli.v3/internal/kube"
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// unmanagedStatePollInterval is how often states of unmanaged modules are read from their module CRs
+// module CRs have kinds known only from ModuleTemplates so they are polled instead of watched
+const unmanagedStatePollInterval = 10 * time.Second
+
+// Watch calls the handler with the modules list every time it changes
+// changes are detected using informers on the Kyma, ModuleTemplate and ModuleReleaseMeta resources
+// and the list is built from the informers cache, states of unmanaged modules are polled from their module CRs
+// it blocks until the context is done or the handler returns an error
+func Watch(ctx context.Context, client kube.Client, opts ListOptions, handler func(ModulesList) error) error {
+	return watch(ctx, client, opts, unmanagedStatePollInterval, handler)
+}
+
+func watch(ctx context.Context, client kube.Client, opts ListOptions, pollInterval time.Duration, handler func(ModulesList) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// buffered channel coalesces events received while the modules list is being built
+	changed := make(chan struct{}, 1)
+	notify := func(interface{}) {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(client.Dynamic(), 0)
+	for _, gvr := range []schema.GroupVersionResource{kyma.GVRKyma, kyma.GVRModuleTemplate, kyma.GVRModuleReleaseMeta} {
+		_, err := factory.ForResource(gvr).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    notify,
+			UpdateFunc: func(_, obj interface{}) { notify(obj) },
+			DeleteFunc: notify,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	factory.Start(ctx.Done())
+	defer func() {
+		// informers must be stopped before the shutdown waits for them
+		cancel()
+		factory.Shutdown()
+	}()
+
+	for gvr, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return errors.New("failed to sync informer for " + gvr.Resource)
+		}
+	}
+
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+
+	var previous ModulesList
+	for {
+		data, err := loadModulesDataFromCache(factory, opts)
+		if err != nil {
+			return err
+		}
+
+		modulesList := buildModulesList(ctx, client, data, opts)
+
+		// keep the order stable to compare lists
+		slices.SortFunc(modulesList, func(a, b Module) int {
+			return cmp.Compare(a.Name, b.Name)
+		})
+
+		if previous == nil || !reflect.DeepEqual(previous, modulesList) {
+			err = handler(modulesList)
+			if err != nil {
+				return err
+			}
+			previous = modulesList
+		}
+
+		// states of unmanaged modules are not tracked by informers so they must be refreshed periodically
+		var pollC <-chan time.Time
+		if slices.ContainsFunc(modulesList, func(module Module) bool {
+			return module.InstallDetails.Managed == ManagedFalse
+		}) {
+			pollC = poll.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		case <-pollC:
+		}
+	}
+}
+
+// loadModulesDataFromCache returns resources the modules list is built from using the synced informers cache
+func loadModulesDataFromCache(factory dynamicinformer.DynamicSharedInformerFactory, opts ListOptions) (*modulesData, error) {
+	filter := kyma.ByModuleName(opts.ModuleNames...)
+
+	moduleTemplates, err := listFromCache[kyma.ModuleTemplateList](factory.ForResource(kyma.GVRModuleTemplate).Lister(), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	releaseMetas, err := listFromCache[kyma.ModuleReleaseMetaList](factory.ForResource(kyma.GVRModuleReleaseMeta).Lister(), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &modulesData{
+		moduleTemplates: moduleTemplates,
+		releaseMetas:    releaseMetas,
+	}
+
+	obj, err := factory.ForResource(kyma.GVRKyma).Lister().ByNamespace(kyma.DefaultKymaNamespace).Get(kyma.DefaultKymaName)
+	if apierrors.IsNotFound(err) {
+		return data, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data.defaultKyma = &kyma.Kyma{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).Object, data.defaultKyma)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// listFromCache converts resources accepted by the filter from the lister to the structured list
+func listFromCache[T any](lister cache.GenericLister, filter kyma.Filter) (*T, error) {
+	objs, err := lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	items := []interface{}{}
+	for _, obj := range objs {
+		u := obj.(*unstructured.Unstructured)
+		if filter(u) {
+			items = append(items, u.Object)
+		}
+	}
+
+	list := new(T)
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(map[string]interface{}{"items": items}, list)
+	return list, err
+}
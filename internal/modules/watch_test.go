@@ -0,0 +1,148 @@
+package modules
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kube_fake "github.com/kyma-project/cli.v3/internal/kube/fake"
+	"github.com/kyma-project/cli.v3/internal/kube/kyma"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamic_fake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestWatch(t *testing.T) {
+	t.Run("call handler on every change", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		scheme.AddKnownTypes(kyma.GVRModuleTemplate.GroupVersion())
+		dynamicClient := dynamic_fake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
+			kyma.GVRModuleTemplate:    "ModuleTemplateList",
+			kyma.GVRModuleReleaseMeta: "ModuleReleaseMetaList",
+			kyma.GVRKyma:              "KymaList",
+		},
+			&testModuleTemplate1,
+			&testReleaseMeta1,
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		lists := make(chan ModulesList)
+		errs := make(chan error)
+		go func() {
+			errs <- Watch(ctx, &kube_fake.FakeKubeClient{
+				TestDynamicInterface: dynamicClient,
+				TestKymaInterface:    kyma.NewClient(dynamicClient),
+			}, ListOptions{}, func(modulesList ModulesList) error {
+				lists <- modulesList
+				return nil
+			})
+		}()
+
+		modulesList := <-lists
+		require.Len(t, modulesList, 1)
+		require.Len(t, modulesList[0].Versions, 1)
+
+		_, err := dynamicClient.Resource(kyma.GVRModuleTemplate).Namespace("kyma-system").
+			Create(ctx, &testModuleTemplate2, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		modulesList = <-lists
+		require.Len(t, modulesList, 1)
+		require.Len(t, modulesList[0].Versions, 2)
+
+		cancel()
+		require.ErrorIs(t, <-errs, context.Canceled)
+
+		// the list is built from the informers cache so resources are listed only once by informers
+		listActions := 0
+		for _, action := range dynamicClient.Actions() {
+			if action.GetVerb() == "list" && action.GetResource() == kyma.GVRModuleTemplate {
+				listActions++
+			}
+		}
+		require.Equal(t, 1, listActions)
+	})
+
+	t.Run("poll state of unmanaged module", func(t *testing.T) {
+		moduleCR := fixTestSecret("cap-live")
+		moduleCR.Object["status"] = map[string]interface{}{"state": "Processing"}
+		dynamicClient, client := fixCommunityKubeClient(t,
+			fixConfigModuleTemplateWithManager(t, "cap-live"),
+			moduleCR,
+			&unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "operator.kyma-project.io/v1beta2",
+					"kind":       "Kyma",
+					"metadata": map[string]interface{}{
+						"name":      "default",
+						"namespace": "kyma-system",
+					},
+					"spec": map[string]interface{}{
+						"modules": []interface{}{
+							map[string]interface{}{"name": "cap", "managed": false},
+						},
+					},
+					"status": map[string]interface{}{
+						"modules": []interface{}{
+							map[string]interface{}{"name": "cap", "version": "0.0.1"},
+						},
+					},
+				},
+			},
+		)
+		client.TestDynamicInterface = dynamicClient
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		lists := make(chan ModulesList)
+		errs := make(chan error)
+		go func() {
+			errs <- watch(ctx, client, ListOptions{}, 10*time.Millisecond, func(modulesList ModulesList) error {
+				lists <- modulesList
+				return nil
+			})
+		}()
+
+		modulesList := <-lists
+		require.Len(t, modulesList, 1)
+		require.Equal(t, "Processing", modulesList[0].InstallDetails.State)
+
+		moduleCR.Object["status"] = map[string]interface{}{"state": "Ready"}
+		_, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "secrets"}).Namespace("kyma-system").
+			Update(ctx, moduleCR, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		modulesList = <-lists
+		require.Equal(t, "Ready", modulesList[0].InstallDetails.State)
+
+		cancel()
+		require.ErrorIs(t, <-errs, context.Canceled)
+	})
+
+	t.Run("return handler error", func(t *testing.T) {
+		scheme := runtime.NewScheme()
+		dynamicClient := dynamic_fake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
+			kyma.GVRModuleTemplate:    "ModuleTemplateList",
+			kyma.GVRModuleReleaseMeta: "ModuleReleaseMetaList",
+			kyma.GVRKyma:              "KymaList",
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		err := Watch(ctx, &kube_fake.FakeKubeClient{
+			TestDynamicInterface: dynamicClient,
+			TestKymaInterface:    kyma.NewClient(dynamicClient),
+		}, ListOptions{}, func(_ ModulesList) error {
+			return errors.New("test error")
+		})
+		require.EqualError(t, err, "test error")
+	})
+}
@@ -0,0 +1,77 @@
+package portforward
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+// ForwardConnection copies data between the local connection and the port of the port-forwarded pod
+// the logic is mostly based on the k8s.io/client-go/tools/portforward package
+// https://github.com/kubernetes/client-go/blob/271d034e86108101a804541843d50abe3fea06ae/tools/portforward/portforward.go#L335
+func ForwardConnection(remoteConn httpstream.Connection, remotePort string, localConn io.ReadWriteCloser) error {
+	defer localConn.Close()
+
+	forwardID := rand.Int()
+
+	// create error stream
+	errorStream, err := createStream(remoteConn, remotePort, v1.StreamTypeError, forwardID)
+	if err != nil {
+		return fmt.Errorf("error creating error stream for port %s: %v", remotePort, err)
+	}
+	// close stream to inform remote server that we are not going to send any data,
+	// and that we are ready to receive the errors
+	errorStream.Close()
+	defer remoteConn.RemoveStreams(errorStream)
+
+	// buffered so the error is not lost if forwarding fails before the error is read
+	errorChan := make(chan error, 1)
+	go handleErrorStream(errorStream, errorChan)
+
+	// create data stream
+	dataStream, err := createStream(remoteConn, remotePort, v1.StreamTypeData, forwardID)
+	if err != nil {
+		return fmt.Errorf("error creating data stream for port %s: %v", remotePort, err)
+	}
+	defer remoteConn.RemoveStreams(dataStream)
+
+	remoteDone := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(localConn, dataStream)
+		remoteDone <- err
+	}()
+
+	localDone := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(dataStream, localConn)
+		// close stream to inform remote server that there is nothing more to receive
+		dataStream.Close()
+		localDone <- err
+	}()
+
+	// wait until the remote server sends everything and stop reading from the local connection
+	remoteErr := <-remoteDone
+	localConn.Close()
+	localErr := <-localDone
+
+	// always expect something on errorChan (it may be nil)
+	forwardErr := <-errorChan
+
+	if remoteErr != nil && !isClosedConnectionErr(remoteErr) {
+		return fmt.Errorf("error reading from remote stream: %v", remoteErr)
+	}
+	if localErr != nil && !isClosedConnectionErr(localErr) {
+		return fmt.Errorf("error writing to remote stream: %v", localErr)
+	}
+
+	return forwardErr
+}
+
+func isClosedConnectionErr(err error) bool {
+	return strings.Contains(err.Error(), "use of closed network connection") ||
+		strings.Contains(err.Error(), "read/write on closed pipe")
+}
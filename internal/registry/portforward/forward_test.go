@@ -0,0 +1,80 @@
+package portforward
+
+import (
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/kyma-project/cli.v3/internal/registry/portforward/automock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+func TestForwardConnection(t *testing.T) {
+	t.Run("copy data between local connection and stream", func(t *testing.T) {
+		client, server := net.Pipe()
+		defer client.Close()
+
+		dataStream := fixCopyDataStreamMock(t, "ping", "pong")
+		remoteConn := fixConnectionMock(t, fixErrStreamMock(t), dataStream)
+
+		errChan := make(chan error)
+		go func() {
+			errChan <- ForwardConnection(remoteConn, "8080", server)
+		}()
+
+		_, err := client.Write([]byte("ping"))
+		require.NoError(t, err)
+
+		response := make([]byte, 4)
+		_, err = io.ReadFull(client, response)
+		require.NoError(t, err)
+		require.Equal(t, "pong", string(response))
+
+		require.NoError(t, <-errChan)
+	})
+
+	t.Run("error from error stream", func(t *testing.T) {
+		client, server := net.Pipe()
+		defer client.Close()
+
+		dataStream := fixCopyDataStreamMock(t, "", "")
+		remoteConn := fixConnectionMock(t, fixBrokenErrStreamMock(t, "connection refused", io.EOF), dataStream)
+
+		err := ForwardConnection(remoteConn, "8080", server)
+		require.EqualError(t, err, "an error occurred while forwarding: connection refused")
+	})
+
+	t.Run("remote stream read error", func(t *testing.T) {
+		client, server := net.Pipe()
+		defer client.Close()
+
+		dataStream := automock.NewStream(t)
+		dataStream.On("Read", mock.Anything).Return(0, errors.New("stream reset")).Once()
+		dataStream.On("Close").Return(nil).Once()
+		remoteConn := fixConnectionMock(t, fixErrStreamMock(t), dataStream)
+
+		err := ForwardConnection(remoteConn, "8080", server)
+		require.EqualError(t, err, "error reading from remote stream: stream reset")
+	})
+}
+
+func fixCopyDataStreamMock(t *testing.T, request, response string) httpstream.Stream {
+	dataStreamMock := automock.NewStream(t)
+	dataStreamMock.On("Close").Return(nil).Once()
+	if request != "" {
+		dataStreamMock.On("Write", []byte(request)).Return(len(request), nil).Once()
+	}
+	if response != "" {
+		dataStreamMock.On("Read", mock.Anything).Run(func(args mock.Arguments) {
+			b, ok := args.Get(0).([]byte)
+			require.True(t, ok)
+
+			copy(b, []byte(response))
+		}).Return(len(response), nil).Once()
+	}
+	dataStreamMock.On("Read", mock.Anything).Return(0, io.EOF).Once()
+	return dataStreamMock
+}